@@ -0,0 +1,269 @@
+package easycmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"os/user"
+	"sync"
+	"time"
+)
+
+// AuditRecord 감사 로그에 기록되는 한 번의 실행
+// 각 레코드는 이전 레코드의 해시(PrevHash)를 포함하여 해시 체인을 이루므로, 중간 레코드를 수정하거나 삭제하면 검증에 실패합니다
+type AuditRecord struct {
+	Seq          int64       `json:"seq"`
+	Time         time.Time   `json:"time"`
+	User         string      `json:"user"`             // 감사 로그를 연 프로세스의 사용자
+	RunAs        *Credential `json:"run_as,omitempty"` // WithCredential, WithUser로 지정한 명령어의 실행 사용자
+	Host         string      `json:"host"`
+	Command      string      `json:"command"`
+	Args         []string    `json:"args"`
+	Dir          string      `json:"dir"`
+	ExitCode     int         `json:"exit_code"`
+	Error        string      `json:"error,omitempty"`
+	DurationMS   int64       `json:"duration_ms"`
+	StdoutSHA256 string      `json:"stdout_sha256"`
+	StderrSHA256 string      `json:"stderr_sha256"`
+	PrevHash     string      `json:"prev_hash"`
+	Hash         string      `json:"hash"`
+}
+
+// computeHash Hash 필드를 제외한 레코드의 SHA-256
+func (r AuditRecord) computeHash() (string, error) {
+	r.Hash = ""
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// AuditSummary 검증한 감사 로그의 레코드 수와 마지막 레코드의 해시
+// 로그 끝의 레코드를 통째로 삭제한 경우는 해시 체인만으로 알 수 없으므로, 별도로 보관했다가 VerifyAuditLogWithAnchor의 기준으로 사용합니다
+type AuditSummary struct {
+	Records  int64
+	LastHash string
+}
+
+// AuditVerifyError 감사 로그 검증 실패
+type AuditVerifyError struct {
+	Line   int // 문제가 발견된 줄 번호 (1부터 시작)
+	Reason string
+}
+
+func (e *AuditVerifyError) Error() string {
+	return fmt.Sprintf("감사 로그 검증 실패 (%d번째 줄): %s", e.Line, e.Reason)
+}
+
+// AuditLog 실행 기록을 해시 체인 JSON 레코드로 파일 끝에 추가하는 감사 로그
+// WithAuditLog 옵션으로 Cmd에 연결하면 Executor로 시작한 모든 프로세스가 기록됩니다
+type AuditLog struct {
+	mu       sync.Mutex
+	file     *os.File
+	seq      int64
+	lastHash string
+	user     string
+	host     string
+}
+
+// OpenAuditLog path의 감사 로그를 엽니다
+// 파일이 없으면 만들고, 있으면 검증한 뒤 마지막 레코드에 이어서 기록합니다 (검증에 실패하면 에러)
+func OpenAuditLog(path string) (*AuditLog, error) {
+	summary, err := VerifyAuditLog(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("감사 로그를 열 수 없습니다: %w", err)
+	}
+
+	a := &AuditLog{file: file, seq: summary.Records, lastHash: summary.LastHash}
+	if u, err := user.Current(); err == nil {
+		a.user = u.Username
+	}
+	a.host, _ = os.Hostname()
+	return a, nil
+}
+
+// LastHash 마지막으로 기록한 레코드의 해시 (로그 끝부분이 잘려나갔는지 확인하기 위해 별도로 보관)
+func (a *AuditLog) LastHash() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.lastHash
+}
+
+// Summary 지금까지 기록한 레코드 수와 마지막 레코드의 해시
+// 로그와 분리된 곳에 보관했다가 VerifyAuditLogWithAnchor에 전달하면 로그 끝의 레코드 삭제를 확인할 수 있습니다
+func (a *AuditLog) Summary() AuditSummary {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return AuditSummary{Records: a.seq, LastHash: a.lastHash}
+}
+
+func (a *AuditLog) Close() error {
+	return a.file.Close()
+}
+
+// append 레코드에 순번과 해시를 채워 파일에 추가합니다
+func (a *AuditLog) append(r AuditRecord) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	r.Seq = a.seq + 1
+	r.User = a.user
+	r.Host = a.host
+	r.PrevHash = a.lastHash
+	h, err := r.computeHash()
+	if err != nil {
+		return err
+	}
+	r.Hash = h
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if _, err := a.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("감사 로그를 기록할 수 없습니다: %w", err)
+	}
+	a.seq = r.Seq
+	a.lastHash = r.Hash
+	return nil
+}
+
+// executor next로 시작한 프로세스를 기록하는 Executor
+func (a *AuditLog) executor(next Executor) Executor {
+	return &auditExecutor{log: a, next: next}
+}
+
+type auditExecutor struct {
+	log  *AuditLog
+	next Executor
+}
+
+func (x *auditExecutor) Start(ctx context.Context, req Request) (Process, error) {
+	r := AuditRecord{Time: time.Now().UTC(), Command: req.Name, Args: req.Args, Dir: req.Dir}
+	if req.Credential != nil {
+		cred := *req.Credential
+		r.RunAs = &cred
+	}
+	stdout, stderr := sha256.New(), sha256.New()
+	if sameWriter(req.Stdout, req.Stderr) {
+		// 2>&1처럼 같은 Writer라면 하나의 스트림을 유지하고, 합쳐진 출력의 해시를 두 필드에 기록합니다
+		stderr = stdout
+		req.Stdout = digestWriter(req.Stdout, stdout)
+		req.Stderr = req.Stdout
+	} else {
+		req.Stdout = digestWriter(req.Stdout, stdout)
+		req.Stderr = digestWriter(req.Stderr, stderr)
+	}
+
+	proc, err := x.next.Start(ctx, req)
+	if err != nil {
+		r.ExitCode = -1
+		r.Error = err.Error()
+		if auditErr := x.log.append(r); auditErr != nil {
+			return nil, errors.Join(err, auditErr)
+		}
+		return nil, err
+	}
+	return &auditProcess{Process: proc, done: func(waitErr error) error {
+		r.DurationMS = time.Since(r.Time).Milliseconds()
+		r.ExitCode = exitCode(waitErr)
+		if waitErr != nil && r.ExitCode < 0 {
+			r.Error = waitErr.Error()
+		}
+		r.StdoutSHA256 = hex.EncodeToString(stdout.Sum(nil))
+		r.StderrSHA256 = hex.EncodeToString(stderr.Sum(nil))
+		return x.log.append(r)
+	}}, nil
+}
+
+type auditProcess struct {
+	Process
+	done func(waitErr error) error
+}
+
+func (p *auditProcess) Wait() error {
+	err := p.Process.Wait()
+	if auditErr := p.done(err); auditErr != nil {
+		return errors.Join(err, auditErr)
+	}
+	return err
+}
+
+// digestWriter w에 쓰는 내용을 h에도 전달합니다 (w가 nil이면 h에만)
+func digestWriter(w io.Writer, h hash.Hash) io.Writer {
+	if w == nil {
+		return h
+	}
+	return io.MultiWriter(w, h)
+}
+
+// VerifyAuditLog 감사 로그의 해시 체인을 검증합니다
+// 레코드가 수정, 삭제, 재배열되었거나 마지막 줄이 중간에 잘린 경우 *AuditVerifyError를 반환합니다
+// 로그 끝의 레코드를 통째로 삭제한 경우는 확인할 수 없으므로 VerifyAuditLogWithAnchor를 사용합니다
+func VerifyAuditLog(path string) (AuditSummary, error) {
+	return VerifyAuditLogWithAnchor(path, AuditSummary{})
+}
+
+// VerifyAuditLogWithAnchor 감사 로그의 해시 체인을 검증하고, 이전에 보관한 anchor의 레코드가 그대로 남아 있는지 확인합니다
+// 로그에는 anchor.Records개 이상의 레코드가 있어야 하며 anchor.Records번째 레코드의 해시가 anchor.LastHash와 같아야 합니다
+// anchor 이후에 추가된 레코드는 허용하므로, 보관한 anchor보다 앞부분까지 잘라낸 경우를 확인할 수 있습니다
+func VerifyAuditLogWithAnchor(path string, anchor AuditSummary) (AuditSummary, error) {
+	var summary AuditSummary
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return summary, err
+	}
+
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		return summary, &AuditVerifyError{Line: bytes.Count(data, []byte("\n")) + 1, Reason: "마지막 레코드가 잘렸습니다"}
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	line := 0
+	for scanner.Scan() {
+		line++
+		var r AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return summary, &AuditVerifyError{Line: line, Reason: fmt.Sprintf("레코드 형식이 잘못되었습니다: %v", err)}
+		}
+		if r.Seq != summary.Records+1 {
+			return summary, &AuditVerifyError{Line: line, Reason: fmt.Sprintf("순번이 %d이어야 하지만 %d입니다", summary.Records+1, r.Seq)}
+		}
+		if r.PrevHash != summary.LastHash {
+			return summary, &AuditVerifyError{Line: line, Reason: "이전 레코드의 해시와 일치하지 않습니다"}
+		}
+		h, err := r.computeHash()
+		if err != nil {
+			return summary, err
+		}
+		if h != r.Hash {
+			return summary, &AuditVerifyError{Line: line, Reason: "레코드 내용이 해시와 일치하지 않습니다"}
+		}
+		if r.Seq == anchor.Records && r.Hash != anchor.LastHash {
+			return summary, &AuditVerifyError{Line: line, Reason: "기준 레코드의 해시와 일치하지 않습니다"}
+		}
+		summary.Records = r.Seq
+		summary.LastHash = r.Hash
+	}
+	if summary.Records < anchor.Records {
+		return summary, &AuditVerifyError{
+			Line:   line + 1,
+			Reason: fmt.Sprintf("레코드가 %d개 이상이어야 하지만 %d개입니다 (로그 끝의 레코드가 삭제되었습니다)", anchor.Records, summary.Records),
+		}
+	}
+	return summary, nil
+}
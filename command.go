@@ -44,6 +44,26 @@ func (c command) Args() []string {
 	return args[1:]
 }
 
+// isWrapped bash 또는 PowerShell로 래핑된 명령어인지 확인
+func (c command) isWrapped() bool {
+	return c.isShell() || c.isPowershell()
+}
+
+// isPowershell RunPowershell 계열로 래핑된 명령어인지 확인
+func (c command) isPowershell() bool {
+	return strings.HasPrefix(string(c), powershellPrefix.String())
+}
+
+// isShell RunShell 계열로 래핑된 명령어인지 확인
+func (c command) isShell() bool {
+	return strings.HasPrefix(string(c), bashPrefix.String())
+}
+
+// script 쉘 래핑을 제거한 스크립트
+func (c command) script() string {
+	return strings.TrimPrefix(string(c), bashPrefix.String())
+}
+
 func (c command) String() string {
 	return string(c)
 }
@@ -12,28 +12,72 @@ type stdOut io.Writer
 type stdErr io.Writer
 
 type config struct {
-	RunDir  runDir
-	StdIn   stdIn
-	StdOut  stdOut
-	StdErr  stdErr
-	Logger  Logger
-	Timeout time.Duration
-	Env     []string
+	RunDir      runDir
+	StdIn       stdIn
+	StdOut      stdOut
+	StdErr      stdErr
+	Logger      Logger
+	Timeout     time.Duration
+	IdleTimeout time.Duration
+	Env         []string
+	LiteralArgs bool
+	ExpandEnv   bool
+	ExpandTilde bool
+	Glob        GlobMode
+	Shell       *Shell
+	ShellFlags  []string
+	ShellStrict bool
+	Executor    Executor
+	DryRun      io.Writer
+	Preflight   bool
+	Policy      *Policy
+	AuditLog    *AuditLog
+
+	ResourceLimits []ResourceLimit
+	Credential     *Credential
+	Nice           *int
+	IOPriority     *IOPriority
+	Sandbox        *Sandbox
+
+	StdOutLineHandler func(line string)
+	StdErrLineHandler func(line string)
+	MaxLineLength     int
+	OutputPrefix      string
+	OutputColor       Color
+
+	err error // 옵션을 적용하거나 검증하는 중 발생한 에러
 }
 
 func (c *config) fillDefault() {
 	if c.StdIn == nil {
 		c.StdIn = os.Stdin
 	}
-	if c.StdOut == nil {
+	if c.StdOut == nil && c.StdOutLineHandler == nil {
 		c.StdOut = os.Stdout
 	}
-	if c.StdErr == nil {
+	if c.StdErr == nil && c.StdErrLineHandler == nil {
 		c.StdErr = os.Stderr
 	}
 	if c.Logger == nil {
 		c.Logger = NewNoOpLogger()
 	}
+	if c.Shell == nil {
+		// 쉘은 Executor와 관계없이 New에서 한 번만 찾습니다
+		shell := discoverShell()
+		c.Shell = &shell
+	}
+	if c.Executor == nil {
+		c.Executor = ExecExecutor{}
+	}
+	if c.AuditLog != nil {
+		c.Executor = c.AuditLog.executor(c.Executor)
+	}
+	if c.Credential != nil && c.err == nil {
+		c.err = validateCredential(*c.Credential)
+	}
+	if c.Sandbox != nil && c.err == nil {
+		c.err = validateSandbox(*c.Sandbox, c.Credential)
+	}
 }
 
 func WithDebug(debugOut ...io.Writer) configApply {
@@ -64,6 +108,43 @@ func WithStdErr(writer io.Writer) configApply {
 	}
 }
 
+func WithStdOutLineHandler(handler func(line string)) configApply {
+	return func(c *config) {
+		c.StdOutLineHandler = handler
+	}
+}
+
+func WithStdErrLineHandler(handler func(line string)) configApply {
+	return func(c *config) {
+		c.StdErrLineHandler = handler
+	}
+}
+
+func WithStdOutLineChan(ch chan<- string) configApply {
+	return func(c *config) {
+		c.StdOutLineHandler = chanLineHandler(ch)
+	}
+}
+
+func WithStdErrLineChan(ch chan<- string) configApply {
+	return func(c *config) {
+		c.StdErrLineHandler = chanLineHandler(ch)
+	}
+}
+
+func WithMaxLineLength(length int) configApply {
+	return func(c *config) {
+		c.MaxLineLength = length
+	}
+}
+
+func WithOutputPrefix(label string, color Color) configApply {
+	return func(c *config) {
+		c.OutputPrefix = label
+		c.OutputColor = color
+	}
+}
+
 func WithTimeout(timeout time.Duration) configApply {
 	return func(c *config) {
 		c.Timeout = timeout
@@ -82,6 +163,141 @@ func WithTimeoutMillis(millis int) configApply {
 	}
 }
 
+func WithLiteralArgs() configApply {
+	return func(c *config) {
+		c.LiteralArgs = true
+	}
+}
+
+func WithExpandEnv() configApply {
+	return func(c *config) {
+		c.ExpandEnv = true
+	}
+}
+
+func WithExpandTilde() configApply {
+	return func(c *config) {
+		c.ExpandTilde = true
+	}
+}
+
+func WithGlob(mode GlobMode) configApply {
+	return func(c *config) {
+		c.Glob = mode
+	}
+}
+
+func WithShell(path string, args ...string) configApply {
+	return func(c *config) {
+		shell := newShell(path, args)
+		c.Shell = &shell
+	}
+}
+
+func WithShellPreset(shell Shell) configApply {
+	return func(c *config) {
+		c.Shell = &shell
+	}
+}
+
+func WithShellFlags(flags ...string) configApply {
+	return func(c *config) {
+		c.ShellFlags = append(c.ShellFlags, flags...)
+	}
+}
+
+func WithShellStrict() configApply {
+	return func(c *config) {
+		c.ShellStrict = true
+	}
+}
+
+func WithExecutor(executor Executor) configApply {
+	return func(c *config) {
+		c.Executor = executor
+	}
+}
+
+func WithDryRun(w io.Writer) configApply {
+	return func(c *config) {
+		c.DryRun = w
+	}
+}
+
+func WithPreflight() configApply {
+	return func(c *config) {
+		c.Preflight = true
+	}
+}
+
+func WithPolicy(policy Policy) configApply {
+	return func(c *config) {
+		c.Policy = &policy
+	}
+}
+
+func WithAuditLog(auditLog *AuditLog) configApply {
+	return func(c *config) {
+		c.AuditLog = auditLog
+	}
+}
+
+func WithResourceLimits(limits ...ResourceLimit) configApply {
+	return func(c *config) {
+		c.ResourceLimits = append(c.ResourceLimits, limits...)
+	}
+}
+
+func WithCredential(uid uint32, gid uint32, groups ...uint32) configApply {
+	return func(c *config) {
+		c.Credential = &Credential{UID: uid, GID: gid, Groups: groups}
+	}
+}
+
+func WithUser(username string) configApply {
+	return func(c *config) {
+		cred, err := lookupCredential(username)
+		if err != nil {
+			c.err = err
+			return
+		}
+		c.Credential = &cred
+	}
+}
+
+func WithNice(n int) configApply {
+	return func(c *config) {
+		if err := validateNice(n); err != nil {
+			c.err = err
+			return
+		}
+		c.Nice = &n
+	}
+}
+
+func WithIOPriority(class IOPriorityClass, level int) configApply {
+	return func(c *config) {
+		priority := IOPriority{Class: class, Level: level}
+		if err := validateIOPriority(priority); err != nil {
+			c.err = err
+			return
+		}
+		c.IOPriority = &priority
+	}
+}
+
+func WithSandbox(sandbox Sandbox) configApply {
+	return func(c *config) {
+		c.Sandbox = &sandbox
+	}
+}
+
+func WithIdleTimeout(timeout time.Duration) configApply {
+	return func(c *config) {
+		c.IdleTimeout = timeout
+	}
+}
+
 func WithEnv(env []string) configApply {
 	return func(c *config) {
 		c.Env = env
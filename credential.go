@@ -0,0 +1,100 @@
+package easycmd
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"strconv"
+)
+
+// Credential 프로세스를 실행할 사용자와 그룹
+type Credential struct {
+	UID      uint32   `json:"uid"`
+	GID      uint32   `json:"gid"`
+	Groups   []uint32 `json:"groups,omitempty"`   // 보조 그룹
+	Username string   `json:"username,omitempty"` // 로그에 표시할 사용자 이름 (WithUser로 지정한 경우)
+}
+
+func (c Credential) String() string {
+	name := ""
+	if c.Username != "" {
+		name = "(" + c.Username + ")"
+	}
+	return fmt.Sprintf("uid=%d%s gid=%d groups=%v", c.UID, name, c.GID, c.Groups)
+}
+
+// lookupCredential 사용자 이름으로 사용자 ID, 기본 그룹, 보조 그룹을 찾습니다
+func lookupCredential(username string) (Credential, error) {
+	u, err := user.Lookup(username)
+	if err != nil {
+		return Credential{}, fmt.Errorf("사용자를 찾을 수 없습니다: %w", err)
+	}
+	uid, err := parseID(u.Uid)
+	if err != nil {
+		return Credential{}, fmt.Errorf("사용자 ID를 사용할 수 없습니다 (%s): %w", username, err)
+	}
+	gid, err := parseID(u.Gid)
+	if err != nil {
+		return Credential{}, fmt.Errorf("그룹 ID를 사용할 수 없습니다 (%s): %w", username, err)
+	}
+
+	cred := Credential{UID: uid, GID: gid, Username: u.Username}
+	groupIDs, err := u.GroupIds()
+	if err != nil {
+		return Credential{}, fmt.Errorf("보조 그룹을 찾을 수 없습니다 (%s): %w", username, err)
+	}
+	for _, g := range groupIDs {
+		id, err := parseID(g)
+		if err != nil {
+			return Credential{}, fmt.Errorf("그룹 ID를 사용할 수 없습니다 (%s): %w", username, err)
+		}
+		if id != gid {
+			cred.Groups = append(cred.Groups, id)
+		}
+	}
+	return cred, nil
+}
+
+func parseID(id string) (uint32, error) {
+	n, err := strconv.ParseUint(id, 10, 32)
+	return uint32(n), err
+}
+
+// checkRedirects cred의 사용자로 실행할 때 파일 리다이렉션이 있으면 에러를 반환합니다
+// 리다이렉션 파일은 프로세스를 시작하기 전에 현재 사용자(root)의 권한으로 열리므로, 실행 사용자가 접근할 수 없는 파일도 열 수 있게 됩니다
+func (p process) checkRedirects(cred *Credential) error {
+	if cred == nil {
+		return nil
+	}
+	for _, r := range p.redirects {
+		if r.op != ">&" {
+			return fmt.Errorf("다른 사용자로 실행할 때는 파일 리다이렉션을 사용할 수 없습니다 (%s): %s", cred, p.display)
+		}
+	}
+	return nil
+}
+
+// chownForCredential 현재 사용자가 만든 임시 파일을 cred의 사용자가 사용할 수 있도록 소유자를 바꿉니다
+func chownForCredential(cred *Credential, paths ...string) error {
+	if cred == nil {
+		return nil
+	}
+	for _, path := range paths {
+		if err := os.Chown(path, int(cred.UID), int(cred.GID)); err != nil {
+			return fmt.Errorf("임시 파일의 소유자를 바꿀 수 없습니다: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateCredential 현재 플랫폼과 권한으로 cred의 사용자로 실행할 수 있는지 확인합니다
+func validateCredential(cred Credential) error {
+	if !credentialSupported {
+		return fmt.Errorf("다른 사용자로 실행하는 기능을 지원하지 않는 OS입니다")
+	}
+	euid := os.Geteuid()
+	if euid != 0 && (int(cred.UID) != euid || int(cred.GID) != os.Getegid()) {
+		return fmt.Errorf("다른 사용자로 실행하려면 root 권한이 필요합니다 (%s)", cred)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package easycmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// dryRunWriteMu 여러 Cmd가 같은 Writer에 동시에 출력해도 줄이 섞이지 않도록 보호합니다
+var dryRunWriteMu sync.Mutex
+
+// dryRun 실행하지 않고 실행할 명령어를 쉘에 붙여넣을 수 있는 형태로 출력합니다
+func dryRun(commandLine string, config config) {
+	if config.RunDir != "" {
+		commandLine = "cd " + quotePosix(string(config.RunDir)) + " && " + commandLine
+	}
+	dryRunWriteMu.Lock()
+	defer dryRunWriteMu.Unlock()
+	fmt.Fprintln(config.DryRun, commandLine)
+}
+
+// shellString 환경변수 지정, 인수, 리다이렉션을 인용부호 처리한 명령어 문자열
+// env(WithEnv)가 있으면 명령어의 환경변수 전체를 대신하므로 env -i로 지정합니다
+// 예: GOOS=linux go build -o 'my app' > build.log, env -i HOME=/root MSG='a b' echo hi
+func (p process) shellString(env []string) string {
+	var parts []string
+	if len(env) > 0 {
+		parts = append(parts, "env", "-i")
+	}
+	for _, kv := range append(append([]string{}, env...), p.env...) {
+		name, value, _ := strings.Cut(kv, "=")
+		parts = append(parts, name+"="+quotePosix(value))
+	}
+	parts = append(parts, quotePosix(p.name))
+	for _, arg := range p.args {
+		parts = append(parts, quotePosix(arg))
+	}
+	for _, r := range p.redirects {
+		parts = append(parts, r.String())
+	}
+	return strings.Join(parts, " ")
+}
+
+// String 쉘에서 사용하는 형태의 리다이렉션 문자열
+// 예: {fd: 2, op: ">&", target: 1} -> 2>&1, {fd: 1, op: ">"} -> > out.txt
+func (r redirect) String() string {
+	fd := strconv.Itoa(r.fd)
+	if (r.op == "<" && r.fd == 0) || (r.op != "<" && r.fd == 1) {
+		fd = ""
+	}
+	if r.op == ">&" {
+		return fd + r.op + r.target.String()
+	}
+	return fd + r.op + " " + quotePosix(r.target.String())
+}
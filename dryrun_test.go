@@ -0,0 +1,35 @@
+package easycmd
+
+import "testing"
+
+func TestProcessShellString(t *testing.T) {
+	tests := []struct {
+		name     string
+		cmd      string
+		env      []string
+		expected string
+	}{
+		{name: "단순 명령어", cmd: "go build ./...", expected: "go build ./..."},
+		{name: "공백과 인용부호", cmd: `echo "hello world" 'it'"'"'s'`, expected: `echo 'hello world' 'it'"'"'s'`},
+		{name: "환경변수 지정", cmd: `GOOS=linux MSG="a b" go build`, expected: "GOOS=linux MSG='a b' go build"},
+		{name: "리다이렉션", cmd: "sort < 'in file' > out.txt 2>&1", expected: "sort < 'in file' > out.txt 2>&1"},
+		{name: "stderr 리다이렉션", cmd: "make 2>> err.log >&2", expected: "make 2>> err.log >&2"},
+		{name: "WithEnv 환경변수", cmd: "GOOS=linux go build", env: []string{"PATH=/usr/bin", "MSG=a b"}, expected: "env -i PATH=/usr/bin MSG='a b' GOOS=linux go build"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := parseCommandList(tt.cmd, operatorEscapable)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p, err := list[0].command.process(tt.cmd, config{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result := p.shellString(tt.env); result != tt.expected {
+				t.Errorf("shellString() = %q, 기대값: %q", result, tt.expected)
+			}
+		})
+	}
+}
@@ -3,8 +3,6 @@ package easycmd
 import (
 	"context"
 	"errors"
-	"fmt"
-	"os/exec"
 )
 
 type Cmd struct {
@@ -25,94 +23,252 @@ func New(configApplies ...configApply) *Cmd {
 	}
 }
 
+// Err New에서 옵션을 검증한 결과 (예: WithUser의 사용자를 찾을 수 없는 경우)
+// 에러가 있으면 모든 Run 계열 메서드가 명령어를 실행하지 않고 이 에러를 반환합니다
+func (c *Cmd) Err() error {
+	return c.c.err
+}
+
 func (c *Cmd) Run(commandStr string) error {
-	return run(command(commandStr), c.c)
+	return run(context.Background(), command(commandStr), c.c)
 }
 
 func (c *Cmd) RunShell(commandStr string) error {
-	return run(command(commandStr).ShellCommand(), c.c)
+	return run(context.Background(), command(commandStr).ShellCommand(), c.c)
+}
+
+// RunShellArgs args를 스크립트의 위치 인수($1, $2, ...)로 전달하여 쉘 스크립트를 실행합니다
+// 값을 스크립트 문자열에 삽입하지 않으므로 신뢰할 수 없는 입력도 안전하게 전달할 수 있습니다
+// 예: cmd.RunShellArgs(`echo "$1"`, "hello; rm -rf /") -> hello; rm -rf /
+func (c *Cmd) RunShellArgs(script string, args ...string) error {
+	if script == "" {
+		return EmptyCmdError
+	}
+	return runShell(context.Background(), script, args, c.c)
 }
 
 func (c *Cmd) RunPowershell(commandStr string) error {
-	return run(command(commandStr).PowershellCommand(), c.c)
+	return run(context.Background(), command(commandStr).PowershellCommand(), c.c)
 }
 
 func (c *Cmd) RunWithDir(commandStr string, runDirStr string) error {
 	config := copyConfigWithDir(c.c, runDirStr)
-	return run(command(commandStr), config)
+	return run(context.Background(), command(commandStr), config)
 }
 
 func (c *Cmd) RunShellWithDir(commandStr string, runDirStr string) error {
 	config := copyConfigWithDir(c.c, runDirStr)
-	return run(command(commandStr).ShellCommand(), config)
+	return run(context.Background(), command(commandStr).ShellCommand(), config)
 }
 
 func (c *Cmd) RunPowershellWithDir(commandStr string, runDirStr string) error {
 	config := copyConfigWithDir(c.c, runDirStr)
-	return run(command(commandStr).PowershellCommand(), config)
+	return run(context.Background(), command(commandStr).PowershellCommand(), config)
 }
 
 func copyConfigWithDir(original config, runDirStr string) config {
-	return config{
-		RunDir:  runDir(runDirStr),
-		StdIn:   original.StdIn,
-		StdOut:  original.StdOut,
-		StdErr:  original.StdErr,
-		Logger:  original.Logger,
-		Timeout: original.Timeout,
-		Env:     original.Env,
-	}
+	copied := original
+	copied.RunDir = runDir(runDirStr)
+	return copied
 }
 
-func run(command command, config config) error {
+func run(parent context.Context, command command, config config) error {
 	if command == "" {
 		return EmptyCmdError
 	}
 
-	config.Logger.ParsedCommand(command.String())
-	config.Logger.ExecutionCommand(command.Name(), command.Args())
+	if command.isShell() {
+		return runShell(parent, command.script(), nil, config)
+	}
+
+	if command.isWrapped() || config.LiteralArgs {
+		p := process{
+			display: command.String(),
+			name:    command.Name(),
+			args:    command.Args(),
+		}
+		if command.isPowershell() {
+			p.mode = modePowershell
+		}
+		return runProcess(parent, p, config)
+	}
+
+	list, err := parseCommandList(command.String(), config.escapableChars())
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return EmptyCmdError
+	}
+	if len(list) == 1 {
+		return runSimpleCommand(parent, list[0].command, command.String(), config)
+	}
+	return runCommandList(parent, list, config)
+}
+
+// runCommandList 명령어 목록을 순서대로 실행합니다
+// &&는 이전 명령어가 성공한 경우에만, ||는 실패한 경우에만 실행하며 ;는 항상 실행합니다
+// 건너뛴 명령어는 결과에 영향을 주지 않으므로 마지막으로 실행된 명령어의 결과를 반환합니다
+// 타임아웃과 유휴 타임아웃은 각 명령어가 아닌 목록 전체에 적용됩니다
+func runCommandList(parent context.Context, list []listItem, config config) error {
+	if config.err != nil {
+		return config.err
+	}
+	e := newExecution(parent, config)
+	defer e.close()
+
+	var err error
+	for _, item := range list {
+		if (item.op == "&&" && err != nil) || (item.op == "||" && err == nil) {
+			continue
+		}
+		if e.ctx.Err() != nil {
+			// 취소되거나 타임아웃된 경우 이전 명령어가 성공했더라도 나머지를 실행하지 않고 에러를 반환합니다
+			if interrupted := e.interruptError(e.ctx.Err()); interrupted != nil {
+				return interrupted
+			}
+			return e.ctx.Err()
+		}
+		p, parseErr := item.command.process(item.source, config)
+		if parseErr != nil {
+			return parseErr
+		}
+		err = e.runProcess(p)
+	}
+	return err
+}
+
+func runSimpleCommand(parent context.Context, sc simpleCommand, display string, config config) error {
+	p, err := sc.process(display, config)
+	if err != nil {
+		return err
+	}
+	return runProcess(parent, p, config)
+}
+
+// process 실행할 하나의 프로세스
+type process struct {
+	display   string // 로그에 표시할 명령어 문자열
+	name      string
+	args      []string
+	env       []string // 명령어 앞에 지정된 환경변수 (예: GOOS=linux)
+	redirects []redirect
+	mode      processMode
+}
+
+// processMode 명령어를 실행하는 방식
+type processMode int
+
+const (
+	modeDirect     processMode = iota
+	modeShell                  // RunShell, RunScript 등 쉘 스크립트 실행
+	modePowershell             // RunPowershell 계열
+)
+
+func (sc simpleCommand) process(display string, config config) (process, error) {
+	if config.ExpandEnv || config.ExpandTilde || config.Glob != GlobOff {
+		expanded, err := sc.expand(config)
+		if err != nil {
+			return process{}, err
+		}
+		sc = expanded
+	}
+	if len(sc.words) == 0 {
+		return process{}, EmptyCmdError
+	}
+
+	args := make([]string, len(sc.words))
+	for i, w := range sc.words {
+		args[i] = w.String()
+	}
+	var env []string
+	for _, w := range sc.assignments {
+		env = append(env, w.String())
+	}
+	return process{
+		display:   display,
+		name:      args[0],
+		args:      args[1:],
+		env:       env,
+		redirects: sc.redirects,
+	}, nil
+}
+
+// runProcess 하나의 프로세스를 실행하고 종료될 때까지 대기합니다
+func runProcess(parent context.Context, p process, config config) error {
+	if config.err != nil {
+		return config.err
+	}
+	e := newExecution(parent, config)
+	defer e.close()
+	return e.runProcess(p)
+}
+
+// runProcess execution의 context와 타임아웃을 적용하여 프로세스를 실행합니다
+func (e *execution) runProcess(p process) error {
+	config := e.config
+	config.Logger.ParsedCommand(p.display)
+	config.Logger.ExecutionCommand(p.name, p.args)
 	config.Logger.ExecutionDirectory(string(config.RunDir))
 	config.Logger.ExecutionStart()
 
-	var cmd *exec.Cmd
-	var ctx context.Context
-	var cancel context.CancelFunc
-
-	if config.Timeout > 0 {
-		ctx, cancel = context.WithTimeout(context.Background(), config.Timeout)
-		defer cancel()
-		cmd = exec.CommandContext(ctx, command.Name(), command.Args()...)
-		config.Logger.Timeout(config.Timeout)
-	} else {
-		cmd = exec.Command(command.Name(), command.Args()...)
+	if config.Policy != nil {
+		checked, violation := config.Policy.check(p, config)
+		if violation != nil {
+			config.Logger.PolicyViolated(violation)
+			return violation
+		}
+		p = checked
 	}
 
-	cmd.Dir = string(config.RunDir)
-	cmd.Stdin = config.StdIn
-	cmd.Stdout = config.StdOut
-	cmd.Stderr = config.StdErr
-	if len(config.Env) > 0 {
-		cmd.Env = config.Env
-		config.Logger.Environment(len(config.Env))
+	if config.Preflight {
+		checked, err := p.preflight(config)
+		if err != nil {
+			config.Logger.StartFailed(err)
+			return err
+		}
+		p = checked
 	}
 
-	if err := cmd.Start(); err != nil {
+	if err := p.checkRedirects(config.Credential); err != nil {
 		config.Logger.StartFailed(err)
-		// 명령어 시작 전 타임아웃 체크
-		if ctx != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
-			return fmt.Errorf("명령어 시작 실패: context deadline exceeded (타임아웃: %s)", config.Timeout)
+		return err
+	}
+
+	if config.DryRun != nil {
+		if len(p.env) > 0 {
+			config.Logger.EnvironmentOverride(p.env)
 		}
-		return fmt.Errorf("명령어를 시작할 수 없습니다: %s", err)
+		dryRun(p.shellString(config.Env), config)
+		config.Logger.ExecutionCompleted()
+		return nil
 	}
-	err := cmd.Wait()
 
+	stdOut, stdErr, flushOutput := config.outputWriters()
+	s := streams{stdIn: config.StdIn, stdOut: stdOut, stdErr: stdErr}
+	closeFiles, err := s.redirect(p.redirects, string(config.RunDir))
 	if err != nil {
-		isTimeout := ctx != nil && errors.Is(ctx.Err(), context.DeadlineExceeded)
-		config.Logger.ExecutionFailed(err, isTimeout)
-		if isTimeout {
-			return fmt.Errorf("명령어 실행 타임아웃: signal: killed (타임아웃: %s)", config.Timeout)
-		}
-		return fmt.Errorf("명령어 실행이 실패했거나 성공적으로 완료되지 않았습니다: %v", err)
+		return e.startError(err)
+	}
+	defer closeFiles()
+
+	req := e.request(p.name, p.args)
+	if len(p.env) > 0 {
+		req.Env = mergeEnv(config.environ(), p.env)
+		config.Logger.EnvironmentOverride(p.env)
+	}
+	req.Stdin = s.stdIn
+	req.Stdout, req.Stderr = e.wrapOutputs(s.stdOut, s.stdErr)
+
+	proc, err := e.start(req)
+	if err != nil {
+		return e.startError(err)
+	}
+	err = proc.Wait()
+	flushOutput()
+
+	if err != nil {
+		return e.waitError(err)
 	}
 
 	config.Logger.ExecutionCompleted()
@@ -121,3 +277,4 @@ func run(command command, config config) error {
 }
 
 var EmptyCmdError = errors.New("empty command")
+var IdleTimeoutError = errors.New("idle timeout")
@@ -0,0 +1,273 @@
+package easycmdtest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seungyeop-lee/easycmd"
+)
+
+// Interaction 카세트에 기록된 한 번의 실행
+type Interaction struct {
+	Name        string   `json:"name"`
+	Args        []string `json:"args"`
+	Dir         string   `json:"dir,omitempty"`
+	EnvKeys     []string `json:"env_keys,omitempty"`     // WithEnv, VAR=value로 지정된 환경변수 이름 (정렬됨)
+	StdinSHA256 string   `json:"stdin_sha256,omitempty"` // 파일이 아닌 stdin의 SHA-256
+	Stdout      string   `json:"stdout"`
+	Stderr      string   `json:"stderr"`
+	ExitCode    int      `json:"exit_code"`
+	Error       string   `json:"error,omitempty"`       // 종료 코드로 나타낼 수 없는 Wait 에러 (예: 시그널로 종료)
+	StartError  string   `json:"start_error,omitempty"` // 프로세스 시작 실패 에러
+	DurationMS  int64    `json:"duration_ms"`
+}
+
+type cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder 다른 Executor로 실행한 결과를 카세트 파일에 기록하는 easycmd.Executor
+// 카세트 파일은 실행이 끝날 때마다 전체를 다시 저장합니다
+//
+//	recorder := easycmdtest.NewRecorder("testdata/release.json", easycmd.ExecExecutor{})
+//	cmd := easycmd.New(easycmd.WithExecutor(recorder))
+type Recorder struct {
+	path string
+	next easycmd.Executor
+
+	mu       sync.Mutex
+	cassette cassette
+}
+
+// NewRecorder next로 실행한 결과를 path에 기록하는 Recorder를 생성합니다
+func NewRecorder(path string, next easycmd.Executor) *Recorder {
+	return &Recorder{path: path, next: next}
+}
+
+func (r *Recorder) Start(ctx context.Context, req easycmd.Request) (easycmd.Process, error) {
+	it := newInteraction(req)
+	stdin, hash, err := readStdin(req.Stdin)
+	if err != nil {
+		return nil, err
+	}
+	req.Stdin = stdin
+	it.StdinSHA256 = hash
+
+	var stdout, stderr bytes.Buffer
+	if sameWriter(req.Stdout, req.Stderr) {
+		// 2>&1처럼 같은 Writer라면 하나의 스트림을 유지하고, 합쳐진 출력을 Stdout에 기록합니다
+		req.Stdout = teeWriter(req.Stdout, &stdout)
+		req.Stderr = req.Stdout
+	} else {
+		req.Stdout = teeWriter(req.Stdout, &stdout)
+		req.Stderr = teeWriter(req.Stderr, &stderr)
+	}
+
+	start := time.Now()
+	proc, err := r.next.Start(ctx, req)
+	if err != nil {
+		it.StartError = err.Error()
+		if saveErr := r.save(it); saveErr != nil {
+			return nil, saveErr
+		}
+		return nil, err
+	}
+	return &recordingProcess{
+		Process: proc,
+		done: func(waitErr error) error {
+			it.DurationMS = time.Since(start).Milliseconds()
+			it.Stdout = stdout.String()
+			it.Stderr = stderr.String()
+			it.ExitCode, it.Error = waitResult(waitErr)
+			return r.save(it)
+		},
+	}, nil
+}
+
+// save it를 카세트에 추가하고 파일에 저장합니다
+func (r *Recorder) save(it Interaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, it)
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(r.path, data, 0o644); err != nil {
+		return fmt.Errorf("easycmdtest: 카세트를 저장할 수 없습니다: %w", err)
+	}
+	return nil
+}
+
+type recordingProcess struct {
+	easycmd.Process
+	done func(waitErr error) error
+}
+
+func (p *recordingProcess) Wait() error {
+	err := p.Process.Wait()
+	if saveErr := p.done(err); saveErr != nil && err == nil {
+		return saveErr
+	}
+	return err
+}
+
+// Replayer 카세트 파일에 기록된 결과를 프로세스를 실행하지 않고 재생하는 easycmd.Executor
+// 명령어 이름, 인수, 디렉토리, 환경변수 이름, stdin이 모두 같은 기록 중 아직 재생하지 않은 첫 기록을 재생하며,
+// 일치하는 기록이 없으면 테스트를 실패시키고 시작 에러를 반환합니다
+type Replayer struct {
+	t testing.TB
+
+	mu           sync.Mutex
+	interactions []Interaction
+	played       []bool
+}
+
+// NewReplayer path의 카세트를 재생하는 Replayer를 생성합니다 (카세트를 읽을 수 없으면 테스트 중단)
+func NewReplayer(t testing.TB, path string) *Replayer {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("easycmdtest: 카세트를 읽을 수 없습니다: %v", err)
+	}
+	var c cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		t.Fatalf("easycmdtest: 카세트 형식이 잘못되었습니다 (%s): %v", path, err)
+	}
+	return &Replayer{t: t, interactions: c.Interactions, played: make([]bool, len(c.Interactions))}
+}
+
+func (r *Replayer) Start(ctx context.Context, req easycmd.Request) (easycmd.Process, error) {
+	want := newInteraction(req)
+	_, hash, err := readStdin(req.Stdin)
+	if err != nil {
+		return nil, err
+	}
+	want.StdinSHA256 = hash
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, it := range r.interactions {
+		if r.played[i] || !it.matches(want) {
+			continue
+		}
+		r.played[i] = true
+		if it.StartError != "" {
+			return nil, errors.New(it.StartError)
+		}
+		return &replayProcess{req: req, it: it}, nil
+	}
+
+	err = fmt.Errorf("easycmdtest: 카세트에 일치하는 기록이 없습니다: %s", Call{Name: req.Name, Args: req.Args})
+	r.t.Error(err)
+	return nil, err
+}
+
+// Unplayed 아직 재생하지 않은 기록의 수
+func (r *Replayer) Unplayed() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, played := range r.played {
+		if !played {
+			n++
+		}
+	}
+	return n
+}
+
+type replayProcess struct {
+	req easycmd.Request
+	it  Interaction
+}
+
+func (p *replayProcess) Wait() error {
+	write(p.req.Stdout, p.it.Stdout)
+	write(p.req.Stderr, p.it.Stderr)
+	if p.it.Error != "" {
+		return errors.New(p.it.Error)
+	}
+	if p.it.ExitCode != 0 {
+		return &easycmd.ExitError{Code: p.it.ExitCode}
+	}
+	return nil
+}
+
+func newInteraction(req easycmd.Request) Interaction {
+	it := Interaction{Name: req.Name, Args: req.Args, Dir: req.Dir}
+	for _, kv := range req.Env {
+		name, _, _ := strings.Cut(kv, "=")
+		it.EnvKeys = append(it.EnvKeys, name)
+	}
+	slices.Sort(it.EnvKeys)
+	return it
+}
+
+func (it Interaction) matches(want Interaction) bool {
+	return it.Name == want.Name &&
+		slices.Equal(it.Args, want.Args) &&
+		it.Dir == want.Dir &&
+		slices.Equal(it.EnvKeys, want.EnvKeys) &&
+		it.StdinSHA256 == want.StdinSHA256
+}
+
+// readStdin stdin을 모두 읽어 SHA-256과 함께 다시 읽을 수 있는 Reader로 반환합니다
+// 터미널, 파이프 등 파일인 stdin은 끝까지 읽으면 대기할 수 있으므로 읽지 않고 그대로 반환합니다
+func readStdin(stdin io.Reader) (io.Reader, string, error) {
+	if stdin == nil {
+		return nil, "", nil
+	}
+	if _, ok := stdin.(*os.File); ok {
+		return stdin, "", nil
+	}
+	data, err := io.ReadAll(stdin)
+	if err != nil {
+		return nil, "", fmt.Errorf("easycmdtest: stdin을 읽을 수 없습니다: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return bytes.NewReader(data), hex.EncodeToString(sum[:]), nil
+}
+
+func teeWriter(w io.Writer, buf *bytes.Buffer) io.Writer {
+	if w == nil {
+		return buf
+	}
+	return io.MultiWriter(w, buf)
+}
+
+// sameWriter os/exec과 같이 두 Writer가 같은 값인지 비교합니다 (비교할 수 없는 타입은 다른 Writer로 봅니다)
+func sameWriter(a, b io.Writer) (same bool) {
+	if a == nil || b == nil {
+		return false
+	}
+	defer func() {
+		if recover() != nil {
+			same = false
+		}
+	}()
+	return a == b
+}
+
+// waitResult Wait 에러를 종료 코드와 종료 코드로 나타낼 수 없는 에러 메시지로 나눕니다
+func waitResult(err error) (int, string) {
+	if err == nil {
+		return 0, ""
+	}
+	var exitErr interface{ ExitCode() int }
+	if errors.As(err, &exitErr) && exitErr.ExitCode() >= 0 {
+		return exitErr.ExitCode(), ""
+	}
+	return -1, err.Error()
+}
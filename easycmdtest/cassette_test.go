@@ -0,0 +1,121 @@
+package easycmdtest_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/seungyeop-lee/easycmd"
+	"github.com/seungyeop-lee/easycmd/easycmdtest"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	// given - 실제 프로세스 실행을 기록
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder := easycmdtest.NewRecorder(path, easycmd.ExecExecutor{})
+	recordOut := &bytes.Buffer{}
+	recordCmd := easycmd.New(
+		easycmd.WithExecutor(recorder),
+		easycmd.WithStdOut(recordOut),
+		easycmd.WithStdErr(recordOut),
+		easycmd.WithStdIn(strings.NewReader("input\n")),
+	)
+	recordErr := recordCmd.Run("cat ; echo done ; sh -c 'echo oops >&2; exit 3'")
+
+	// when - 기록을 재생
+	replayer := easycmdtest.NewReplayer(t, path)
+	replayOut := &bytes.Buffer{}
+	replayCmd := easycmd.New(
+		easycmd.WithExecutor(replayer),
+		easycmd.WithStdOut(replayOut),
+		easycmd.WithStdErr(replayOut),
+		easycmd.WithStdIn(strings.NewReader("input\n")),
+	)
+	replayErr := replayCmd.Run("cat ; echo done ; sh -c 'echo oops >&2; exit 3'")
+
+	// then
+	if recordOut.String() != "input\ndone\noops\n" {
+		t.Fatalf("unexpected recorded output: %q", recordOut.String())
+	}
+	if replayOut.String() != recordOut.String() {
+		t.Errorf("expected %q, got %q", recordOut.String(), replayOut.String())
+	}
+	var exitErr *easycmd.ExitError
+	if recordErr == nil || !errors.As(replayErr, &exitErr) || exitErr.Code != 3 {
+		t.Errorf("expected exit code 3, got record: %v, replay: %v", recordErr, replayErr)
+	}
+	if replayer.Unplayed() != 0 {
+		t.Errorf("expected all interactions played, %d left", replayer.Unplayed())
+	}
+}
+
+func TestRecordSharedOutput(t *testing.T) {
+	// given - stdout과 stderr에 같은 Writer 사용
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder := easycmdtest.NewRecorder(path, easycmd.ExecExecutor{})
+	recordOut := &bytes.Buffer{}
+	script := "for i in 1 2 3 4 5; do echo out$i; echo err$i >&2; done"
+	easycmd.New(
+		easycmd.WithExecutor(recorder),
+		easycmd.WithStdOut(recordOut),
+		easycmd.WithStdErr(recordOut),
+	).RunShell(script)
+
+	// when
+	replayOut := &bytes.Buffer{}
+	replayErr := easycmd.New(
+		easycmd.WithExecutor(easycmdtest.NewReplayer(t, path)),
+		easycmd.WithStdOut(replayOut),
+		easycmd.WithStdErr(replayOut),
+	).RunShell(script)
+
+	// then - 기록과 재생 모두 출력 순서가 유지되어야 함
+	var expected strings.Builder
+	for i := 1; i <= 5; i++ {
+		fmt.Fprintf(&expected, "out%d\nerr%d\n", i, i)
+	}
+	if recordOut.String() != expected.String() {
+		t.Errorf("expected %q, got %q", expected.String(), recordOut.String())
+	}
+	if replayErr != nil || replayOut.String() != expected.String() {
+		t.Errorf("expected %q, got %q (%v)", expected.String(), replayOut.String(), replayErr)
+	}
+}
+
+func TestReplayUnmatched(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder := easycmdtest.NewRecorder(path, easycmd.ExecExecutor{})
+	easycmd.New(easycmd.WithExecutor(recorder), easycmd.WithStdOut(&bytes.Buffer{})).Run("echo recorded")
+
+	rt := &recordingTB{TB: t}
+	replayer := easycmdtest.NewReplayer(rt, path)
+	cmd := easycmd.New(easycmd.WithExecutor(replayer))
+
+	// when
+	err := cmd.Run("echo different")
+
+	// then
+	if err == nil {
+		t.Error("expected error, got nil")
+	}
+	if len(rt.errors) != 1 || !strings.Contains(rt.errors[0], "echo different") {
+		t.Errorf("expected test failure for unmatched command, got %q", rt.errors)
+	}
+	if replayer.Unplayed() != 1 {
+		t.Errorf("expected 1 unplayed interaction, got %d", replayer.Unplayed())
+	}
+}
+
+// recordingTB 테스트를 실패시키지 않고 에러 메시지를 기록하는 testing.TB
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Error(args ...any) {
+	r.errors = append(r.errors, strings.TrimSpace(fmt.Sprintln(args...)))
+}
@@ -0,0 +1,221 @@
+// Package easycmdtest 프로세스를 실행하지 않고 easycmd.Cmd를 사용하는 코드를 테스트하기 위한 도구
+package easycmdtest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seungyeop-lee/easycmd"
+)
+
+// Fake 규칙에 따라 미리 정한 결과를 반환하는 easycmd.Executor
+// 실행 요청은 추가된 순서대로 규칙과 비교하여 처음 일치한 규칙의 결과를 반환하며, 일치하는 규칙이 없으면 시작 에러를 반환합니다
+//
+//	fake := easycmdtest.NewFake()
+//	fake.On("git", "rev-parse", "HEAD").Stdout("abc123\n")
+//	cmd := easycmd.New(easycmd.WithExecutor(fake))
+type Fake struct {
+	mu    sync.Mutex
+	rules []*Rule
+	calls []Call
+}
+
+// NewFake Fake 인스턴스를 생성합니다
+func NewFake() *Fake {
+	return &Fake{}
+}
+
+// Call Fake로 요청된 실행 기록
+type Call struct {
+	Name string
+	Args []string
+	Dir  string
+	Env  []string
+}
+
+// String "name arg1 arg2" 형식의 명령어 문자열
+func (c Call) String() string {
+	return strings.Join(append([]string{c.Name}, c.Args...), " ")
+}
+
+// On name과 args가 정확히 일치하는 실행에 대한 규칙을 추가합니다
+func (f *Fake) On(name string, args ...string) *Rule {
+	r := &Rule{name: name, args: args, matchArgs: true, env: map[string]string{}}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rules = append(f.rules, r)
+	return r
+}
+
+// Start 일치하는 규칙을 찾아 실행 기록을 남기고 규칙의 결과를 반환하는 프로세스를 만듭니다
+func (f *Fake) Start(ctx context.Context, req easycmd.Request) (easycmd.Process, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, Call{Name: req.Name, Args: req.Args, Dir: req.Dir, Env: req.Env})
+	for _, r := range f.rules {
+		if r.matches(req) {
+			if r.startErr != nil {
+				return nil, r.startErr
+			}
+			return &fakeProcess{ctx: ctx, req: req, rule: r}, nil
+		}
+	}
+	return nil, fmt.Errorf("easycmdtest: 일치하는 규칙이 없습니다: %s", Call{Name: req.Name, Args: req.Args})
+}
+
+// Calls 지금까지 요청된 실행 기록 (일치하는 규칙이 없었던 요청 포함)
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.calls)
+}
+
+// AssertCalls 요청된 실행의 순서와 명령어 문자열이 commands와 같은지 확인합니다
+// 예: fake.AssertCalls(t, "go build ./...", "go test ./...")
+func (f *Fake) AssertCalls(t testing.TB, commands ...string) {
+	t.Helper()
+	calls := f.Calls()
+	actual := make([]string, len(calls))
+	for i, c := range calls {
+		actual[i] = c.String()
+	}
+	if !slices.Equal(actual, commands) {
+		t.Errorf("easycmdtest: 실행된 명령어가 다릅니다\n실제: %q\n기대: %q", actual, commands)
+	}
+}
+
+// Rule 실행 요청과 비교할 조건과 반환할 결과
+type Rule struct {
+	name      string
+	args      []string
+	matchArgs bool
+	dir       *string
+	env       map[string]string
+
+	stdout   string
+	stderr   string
+	exitCode int
+	delay    time.Duration
+	startErr error
+	handler  func(req easycmd.Request) int
+}
+
+// AnyArgs 인수와 관계없이 일치하도록 합니다
+func (r *Rule) AnyArgs() *Rule {
+	r.matchArgs = false
+	return r
+}
+
+// InDir 실행 디렉토리가 dir인 경우에만 일치하도록 합니다
+func (r *Rule) InDir(dir string) *Rule {
+	r.dir = &dir
+	return r
+}
+
+// WithEnv 실제로 적용될 환경변수 name의 값이 value인 경우에만 일치하도록 합니다
+func (r *Rule) WithEnv(name string, value string) *Rule {
+	r.env[name] = value
+	return r
+}
+
+// Stdout 표준 출력으로 쓸 내용을 지정합니다
+func (r *Rule) Stdout(out string) *Rule {
+	r.stdout = out
+	return r
+}
+
+// Stderr 표준 에러로 쓸 내용을 지정합니다
+func (r *Rule) Stderr(out string) *Rule {
+	r.stderr = out
+	return r
+}
+
+// ExitCode 종료 코드를 지정합니다 (0이 아니면 Wait가 *easycmd.ExitError를 반환)
+func (r *Rule) ExitCode(code int) *Rule {
+	r.exitCode = code
+	return r
+}
+
+// Delay 결과를 반환하기 전 대기 시간을 지정합니다 (타임아웃, 취소 테스트용)
+func (r *Rule) Delay(delay time.Duration) *Rule {
+	r.delay = delay
+	return r
+}
+
+// StartError 프로세스 시작 실패를 지정합니다 (예: 실행 파일이 없는 경우)
+func (r *Rule) StartError(err error) *Rule {
+	r.startErr = err
+	return r
+}
+
+// Handle 요청을 직접 처리하는 함수를 지정합니다
+// handler는 Stdout, Stderr로 지정한 내용을 쓴 뒤 호출되며, req.Stdin을 읽거나 req.Stdout에 쓸 수 있고 종료 코드를 반환합니다
+func (r *Rule) Handle(handler func(req easycmd.Request) int) *Rule {
+	r.handler = handler
+	return r
+}
+
+func (r *Rule) matches(req easycmd.Request) bool {
+	if req.Name != r.name {
+		return false
+	}
+	if r.matchArgs && !slices.Equal(req.Args, r.args) {
+		return false
+	}
+	if r.dir != nil && req.Dir != *r.dir {
+		return false
+	}
+	env := req.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	for name, value := range r.env {
+		if !slices.Contains(env, name+"="+value) {
+			return false
+		}
+	}
+	return true
+}
+
+type fakeProcess struct {
+	ctx  context.Context
+	req  easycmd.Request
+	rule *Rule
+}
+
+func (p *fakeProcess) Wait() error {
+	if p.rule.delay > 0 {
+		timer := time.NewTimer(p.rule.delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-p.ctx.Done():
+			return fmt.Errorf("easycmdtest: %w", p.ctx.Err())
+		}
+	}
+
+	write(p.req.Stdout, p.rule.stdout)
+	write(p.req.Stderr, p.rule.stderr)
+	code := p.rule.exitCode
+	if p.rule.handler != nil {
+		code = p.rule.handler(p.req)
+	}
+	if code != 0 {
+		return &easycmd.ExitError{Code: code}
+	}
+	return nil
+}
+
+func write(w io.Writer, s string) {
+	if w != nil && s != "" {
+		io.WriteString(w, s)
+	}
+}
@@ -0,0 +1,182 @@
+package easycmdtest_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seungyeop-lee/easycmd"
+	"github.com/seungyeop-lee/easycmd/easycmdtest"
+)
+
+func TestFakeStdoutAndExitCode(t *testing.T) {
+	// given
+	fake := easycmdtest.NewFake()
+	fake.On("git", "rev-parse", "HEAD").Stdout("abc123\n")
+	fake.On("git", "push").Stderr("rejected\n").ExitCode(1)
+
+	out := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithExecutor(fake), easycmd.WithStdOut(out), easycmd.WithStdErr(errOut))
+
+	// when
+	revErr := cmd.Run("git rev-parse HEAD")
+	pushErr := cmd.Run("git push")
+
+	// then
+	if revErr != nil {
+		t.Errorf("expected nil, got %v", revErr)
+	}
+	if out.String() != "abc123\n" {
+		t.Errorf("expected 'abc123', got %q", out.String())
+	}
+	var exitErr *easycmd.ExitError
+	if !errors.As(pushErr, &exitErr) || exitErr.Code != 1 {
+		t.Errorf("expected exit code 1, got %v", pushErr)
+	}
+	if errOut.String() != "rejected\n" {
+		t.Errorf("expected 'rejected', got %q", errOut.String())
+	}
+}
+
+func TestFakeMatchDirAndEnv(t *testing.T) {
+	// given
+	fake := easycmdtest.NewFake()
+	fake.On("go", "build").InDir("/src").WithEnv("GOOS", "linux").Stdout("linux\n")
+	fake.On("go").AnyArgs().Stdout("other\n")
+
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithExecutor(fake), easycmd.WithStdOut(out))
+
+	// when
+	err := cmd.RunWithDir("GOOS=linux go build && go build && go vet", "/src")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "linux\nother\nother\n" {
+		t.Errorf("unexpected output: %q", out.String())
+	}
+	fake.AssertCalls(t, "go build", "go build", "go vet")
+	if calls := fake.Calls(); calls[0].Dir != "/src" {
+		t.Errorf("expected dir /src, got %q", calls[0].Dir)
+	}
+}
+
+func TestFakeUnmatched(t *testing.T) {
+	// given
+	fake := easycmdtest.NewFake()
+	cmd := easycmd.New(easycmd.WithExecutor(fake))
+
+	// when
+	err := cmd.Run("rm -rf /")
+
+	// then
+	if err == nil || !strings.Contains(err.Error(), "rm -rf /") {
+		t.Errorf("expected unmatched error, got %v", err)
+	}
+	fake.AssertCalls(t, "rm -rf /")
+}
+
+func TestFakeDelayWithTimeout(t *testing.T) {
+	// given
+	fake := easycmdtest.NewFake()
+	fake.On("sleep", "10").Delay(10 * time.Second)
+	cmd := easycmd.New(easycmd.WithExecutor(fake), easycmd.WithTimeout(50*time.Millisecond))
+
+	// when
+	start := time.Now()
+	err := cmd.Run("sleep 10")
+
+	// then
+	if err == nil || !strings.Contains(err.Error(), "타임아웃") {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("expected to stop at timeout, took %s", time.Since(start))
+	}
+}
+
+func TestFakePipeline(t *testing.T) {
+	// given - Handle로 stdin을 읽는 단계
+	fake := easycmdtest.NewFake()
+	fake.On("git", "log").Stdout("fix a\nfeat b\nfix c\n")
+	fake.On("grep", "fix").Handle(func(req easycmd.Request) int {
+		data, _ := io.ReadAll(req.Stdin)
+		for _, line := range strings.SplitAfter(string(data), "\n") {
+			if strings.Contains(line, "fix") {
+				io.WriteString(req.Stdout, line)
+			}
+		}
+		return 0
+	})
+
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithExecutor(fake), easycmd.WithStdOut(out))
+
+	// when
+	result, err := cmd.RunPipeline("git log", "grep fix")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "fix a\nfix c\n" {
+		t.Errorf("unexpected output: %q", out.String())
+	}
+	if result.ExitCodes[0] != 0 || result.ExitCodes[1] != 0 {
+		t.Errorf("expected exit codes [0 0], got %v", result.ExitCodes)
+	}
+}
+
+func TestFakeShellPreset(t *testing.T) {
+	// given - Fake로 쉘 명령어를 기대할 때는 PATH의 실제 쉘과 관계없도록 쉘을 지정
+	binDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binDir, "sh"), []byte("#!/bin/false\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir)
+	fake := easycmdtest.NewFake()
+	fake.On("bash", "-c", "echo hi").Stdout("hi\n")
+
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithExecutor(fake),
+		easycmd.WithShellPreset(easycmd.ShellBash),
+		easycmd.WithStdOut(out),
+	)
+
+	// when
+	err := cmd.RunShell("echo hi")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "hi\n" {
+		t.Errorf("expected 'hi', got %q", out.String())
+	}
+	fake.AssertCalls(t, "bash -c echo hi")
+}
+
+func TestFakeShellTemplatePowershellQuoting(t *testing.T) {
+	// given
+	fake := easycmdtest.NewFake()
+	fake.On("pwsh").AnyArgs()
+	cmd := easycmd.New(easycmd.WithExecutor(fake), easycmd.WithShellPreset(easycmd.ShellPwsh))
+
+	// when - pwsh로 실행하면 값도 PowerShell 방식으로 인용부호 처리되어야 함
+	err := cmd.RunShellTemplate("Write-Output {{.}}", "it's $HOME")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	fake.AssertCalls(t, "pwsh -NoProfile -Command Write-Output 'it''s $HOME'")
+}
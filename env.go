@@ -0,0 +1,55 @@
+package easycmd
+
+import (
+	"os"
+	"strings"
+)
+
+// environ 명령어에 적용될 환경변수 (WithEnv 설정이 없으면 현재 프로세스의 환경변수)
+func (c config) environ() []string {
+	if len(c.Env) > 0 {
+		return c.Env
+	}
+	return os.Environ()
+}
+
+// mergeEnv base의 환경변수에 overrides를 덮어쓴 새 목록을 반환합니다
+// 예: mergeEnv([]string{"A=1", "B=2"}, []string{"B=3", "C=4"}) -> [A=1 B=3 C=4]
+func mergeEnv(base []string, overrides []string) []string {
+	merged := make([]string, 0, len(base)+len(overrides))
+	index := make(map[string]int, len(base))
+	for _, kv := range append(append([]string{}, base...), overrides...) {
+		name, _, _ := strings.Cut(kv, "=")
+		if i, ok := index[name]; ok {
+			merged[i] = kv
+			continue
+		}
+		index[name] = len(merged)
+		merged = append(merged, kv)
+	}
+	return merged
+}
+
+// isAssignment 인용부호 밖의 "NAME=value" 형식으로 시작하는 단어인지 확인
+// 예: GOOS=linux -> true, "GOOS=linux" -> false, =value -> false
+func isAssignment(w word) bool {
+	if len(w) == 0 || w[0].quote != 0 {
+		return false
+	}
+	name, _, found := strings.Cut(w[0].text, "=")
+	return found && isEnvName(name)
+}
+
+// isEnvName 환경변수 이름으로 사용할 수 있는지 확인 ([A-Za-z_][A-Za-z0-9_]*)
+func isEnvName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9') {
+			continue
+		}
+		return false
+	}
+	return true
+}
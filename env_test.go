@@ -0,0 +1,69 @@
+package easycmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeEnv(t *testing.T) {
+	tests := []struct {
+		name      string
+		base      []string
+		overrides []string
+		expected  []string
+	}{
+		{
+			name:      "새 변수 추가",
+			base:      []string{"A=1"},
+			overrides: []string{"B=2"},
+			expected:  []string{"A=1", "B=2"},
+		},
+		{
+			name:      "기존 변수 덮어쓰기",
+			base:      []string{"A=1", "B=2"},
+			overrides: []string{"A=3"},
+			expected:  []string{"A=3", "B=2"},
+		},
+		{
+			name:      "같은 변수를 여러 번 지정하면 마지막 값",
+			base:      nil,
+			overrides: []string{"A=1", "A=2"},
+			expected:  []string{"A=2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := mergeEnv(tt.base, tt.overrides)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("mergeEnv() = %v, 기대값: %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsAssignment(t *testing.T) {
+	tests := []struct {
+		name     string
+		word     word
+		expected bool
+	}{
+		{name: "일반 지정", word: word{{text: "GOOS=linux"}}, expected: true},
+		{name: "빈 값", word: word{{text: "A="}}, expected: true},
+		{name: "인용부호 값", word: word{{text: "A="}, {text: "a b", quote: '"'}}, expected: true},
+		{name: "밑줄과 숫자", word: word{{text: "_MY_VAR2=x"}}, expected: true},
+		{name: "숫자로 시작", word: word{{text: "2A=x"}}, expected: false},
+		{name: "이름 없음", word: word{{text: "=x"}}, expected: false},
+		{name: "옵션 형식", word: word{{text: "--name=x"}}, expected: false},
+		{name: "인용부호 안의 이름", word: word{{text: "A=x", quote: '\''}}, expected: false},
+		{name: "등호 없음", word: word{{text: "make"}}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := isAssignment(tt.word); result != tt.expected {
+				t.Errorf("isAssignment(%q) = %v, 기대값: %v", tt.word.String(), result, tt.expected)
+			}
+		})
+	}
+}
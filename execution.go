@@ -0,0 +1,163 @@
+package easycmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const waitDelay = time.Second
+
+// execution 한 번의 실행에 적용되는 context와 타임아웃 상태
+// 파이프라인처럼 여러 프로세스가 함께 실행되는 경우에도 하나의 execution을 공유합니다
+type execution struct {
+	parent  context.Context
+	ctx     context.Context
+	idle    *idleWatcher
+	config  config
+	cancels []context.CancelFunc
+}
+
+func newExecution(parent context.Context, config config) *execution {
+	e := &execution{parent: parent, ctx: parent, config: config}
+
+	if config.Timeout > 0 {
+		ctx, cancel := context.WithTimeout(e.ctx, config.Timeout)
+		e.ctx = ctx
+		e.cancels = append(e.cancels, cancel)
+		config.Logger.Timeout(config.Timeout)
+	}
+
+	if config.IdleTimeout > 0 {
+		ctx, cancel := context.WithCancel(e.ctx)
+		e.ctx = ctx
+		e.cancels = append(e.cancels, cancel)
+		e.idle = newIdleWatcher(config.IdleTimeout, cancel)
+		config.Logger.IdleTimeout(config.IdleTimeout)
+	}
+
+	if len(config.Env) > 0 {
+		config.Logger.Environment(len(config.Env))
+	}
+
+	if config.Credential != nil {
+		config.Logger.Credential(*config.Credential)
+	}
+
+	if config.Sandbox != nil {
+		config.Logger.Sandbox(*config.Sandbox)
+	}
+
+	return e
+}
+
+// withCancel 실행 context를 직접 취소할 수 있도록 합니다 (예: 파이프라인 일부 단계의 시작 실패)
+func (e *execution) withCancel() context.CancelFunc {
+	ctx, cancel := context.WithCancel(e.ctx)
+	e.ctx = ctx
+	e.cancels = append(e.cancels, cancel)
+	return cancel
+}
+
+func (e *execution) close() {
+	if e.idle != nil {
+		e.idle.stop()
+	}
+	for _, cancel := range e.cancels {
+		cancel()
+	}
+}
+
+// request 실행 디렉토리와 환경변수가 적용된 Request를 만듭니다
+func (e *execution) request(name string, args []string) Request {
+	req := Request{
+		Name:           name,
+		Args:           args,
+		Dir:            string(e.config.RunDir),
+		ResourceLimits: e.config.ResourceLimits,
+		Credential:     e.config.Credential,
+		Nice:           e.config.Nice,
+		IOPriority:     e.config.IOPriority,
+		Sandbox:        e.config.Sandbox,
+	}
+	if len(e.config.Env) > 0 {
+		req.Env = e.config.Env
+	}
+	return req
+}
+
+// start 설정된 Executor로 실행 context를 적용하여 프로세스를 시작합니다
+func (e *execution) start(req Request) (Process, error) {
+	return e.config.Executor.Start(e.ctx, req)
+}
+
+// wrapOutput 유휴 타임아웃이 설정된 경우 출력을 감시하는 Writer로 감쌉니다
+func (e *execution) wrapOutput(out io.Writer) io.Writer {
+	if e.idle == nil {
+		return out
+	}
+	return e.idle.wrap(out)
+}
+
+// wrapOutputs stdout과 stderr을 wrapOutput으로 감쌉니다
+// 두 Writer가 같으면 하나의 Writer로 감싸 os/exec이 하나의 복사 고루틴으로 두 출력을 처리하도록 합니다
+func (e *execution) wrapOutputs(stdOut, stdErr io.Writer) (io.Writer, io.Writer) {
+	if sameWriter(stdOut, stdErr) {
+		out := e.wrapOutput(stdOut)
+		return out, out
+	}
+	return e.wrapOutput(stdOut), e.wrapOutput(stdErr)
+}
+
+func (e *execution) startError(err error) error {
+	e.config.Logger.StartFailed(err)
+	if e.parent.Err() != nil {
+		return fmt.Errorf("명령어 시작 실패: %w", e.parent.Err())
+	}
+	// 명령어 시작 전 타임아웃 체크
+	if errors.Is(e.ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("명령어 시작 실패: context deadline exceeded (타임아웃: %s)", e.config.Timeout)
+	}
+	return fmt.Errorf("명령어를 시작할 수 없습니다: %s", err)
+}
+
+func (e *execution) waitError(err error) error {
+	if interrupted := e.interruptError(err); interrupted != nil {
+		return interrupted
+	}
+	if limitErr := e.limitError(err); limitErr != nil {
+		return limitErr
+	}
+	e.config.Logger.ExecutionFailed(err, false)
+	return fmt.Errorf("명령어 실행이 실패했거나 성공적으로 완료되지 않았습니다: %w", err)
+}
+
+// limitError 리소스 제한 초과로 종료된 경우 ResourceLimitError를 반환합니다
+func (e *execution) limitError(err error) error {
+	limit, ok := exceededLimit(err, e.config.ResourceLimits)
+	if !ok {
+		return nil
+	}
+	e.config.Logger.ResourceLimitExceeded(limit)
+	return &ResourceLimitError{Limit: limit, Err: err}
+}
+
+// interruptError 유휴 타임아웃, 취소, 타임아웃으로 중단된 경우 해당 에러를 반환합니다
+// 중단되지 않았다면 nil을 반환합니다
+func (e *execution) interruptError(err error) error {
+	if e.idle != nil && e.idle.isExceeded() {
+		e.config.Logger.IdleTimeoutExceeded(e.config.IdleTimeout)
+		return fmt.Errorf("명령어 유휴 타임아웃: %s 동안 출력이 없습니다: %w", e.config.IdleTimeout, IdleTimeoutError)
+	}
+	if e.parent.Err() != nil {
+		e.config.Logger.ExecutionFailed(err, false)
+		return fmt.Errorf("명령어 실행이 취소되었습니다: %w", e.parent.Err())
+	}
+	if errors.Is(e.ctx.Err(), context.DeadlineExceeded) {
+		e.config.Logger.ExecutionFailed(err, true)
+		return fmt.Errorf("명령어 실행 타임아웃: signal: killed (타임아웃: %s)", e.config.Timeout)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package easycmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+// Executor 프로세스를 시작하는 방식
+// Cmd는 명령어 파싱, 리다이렉션, 타임아웃 등을 처리한 뒤 Executor로 프로세스를 시작합니다
+// 기본값은 os/exec를 사용하는 ExecExecutor이며, 테스트에서는 easycmdtest.Fake로 대체할 수 있습니다
+type Executor interface {
+	// Start 프로세스를 시작합니다
+	// ctx가 종료(타임아웃, 취소)되면 프로세스를 종료해야 합니다
+	Start(ctx context.Context, req Request) (Process, error)
+}
+
+// Process Executor로 시작된 프로세스
+type Process interface {
+	// Wait 프로세스가 끝날 때까지 대기합니다
+	// 0이 아닌 종료 코드로 끝난 경우 ExitCode() int 메서드를 가진 에러(*exec.ExitError, *ExitError)를 반환합니다
+	Wait() error
+}
+
+// Request 시작할 프로세스의 정보
+type Request struct {
+	Name   string
+	Args   []string
+	Dir    string   // 빈 문자열이면 현재 디렉토리
+	Env    []string // nil이면 현재 프로세스의 환경변수
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+
+	ResourceLimits []ResourceLimit // 프로세스를 시작한 직후 적용할 리소스 제한 (Linux)
+	Credential     *Credential     // nil이면 현재 사용자로 실행
+	Nice           *int            // 프로세스를 시작한 직후 적용할 nice 값 (Unix)
+	IOPriority     *IOPriority     // 프로세스를 시작한 직후 적용할 I/O 우선순위 (Linux)
+	Sandbox        *Sandbox        // nil이 아니면 새 네임스페이스에서 실행 (Linux)
+}
+
+// ExitError 0이 아닌 종료 코드로 끝난 프로세스의 에러 (Executor 구현에서 사용)
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+func (e *ExitError) ExitCode() int {
+	return e.Code
+}
+
+// exitCode Process.Wait가 반환한 에러의 종료 코드
+// 시그널로 종료되었거나 종료 코드를 알 수 없으면 -1입니다
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr interface{ ExitCode() int }
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// ExecExecutor os/exec로 프로세스를 시작하는 기본 Executor
+type ExecExecutor struct{}
+
+func (ExecExecutor) Start(ctx context.Context, req Request) (Process, error) {
+	cmd := exec.CommandContext(ctx, req.Name, req.Args...)
+	cmd.Dir = req.Dir
+	cmd.Env = req.Env
+	cmd.Stdin = req.Stdin
+	cmd.Stdout = req.Stdout
+	cmd.Stderr = req.Stderr
+	if ctx.Done() != nil {
+		// 종료된 프로세스의 자식이 출력 파이프를 잡고 있어도 Wait가 무한정 대기하지 않도록 합니다
+		cmd.WaitDelay = waitDelay
+	}
+	if err := checkResourceLimits(req.ResourceLimits); err != nil {
+		return nil, err
+	}
+	attr, err := sysProcAttr(req)
+	if err != nil {
+		return nil, err
+	}
+	cmd.SysProcAttr = attr
+	if needsHelper(req) {
+		// 샌드박스, 리소스 제한, 우선순위는 명령어를 실행하기 전에 적용
+		if err := startHelper(cmd, req); err != nil {
+			return nil, err
+		}
+		return cmd, nil
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	// HelperMain을 호출하지 않은 경우 우선순위는 명령어가 시작된 직후 적용되므로, 처음 잠깐은 기본 우선순위로 실행됩니다
+	if err := applyPriority(cmd.Process.Pid, req); err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return nil, err
+	}
+	return cmd, nil
+}
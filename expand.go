@@ -0,0 +1,212 @@
+package easycmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// expand 설정에 따라 환경변수 지정, 인수, 리다이렉션 대상을 확장합니다
+// bash와 같이 틸드 확장, 변수 확장, glob 확장 순서로 적용하며 확장 후 빈 문자열이 된 인수는 제거합니다
+// 환경변수 지정 값은 config의 환경변수로, 나머지는 환경변수 지정을 적용한 명령어의 실제 환경변수로 확장합니다
+func (sc simpleCommand) expand(config config) (simpleCommand, error) {
+	var expanded simpleCommand
+	base := config.environ()
+
+	var env []string
+	baseExpander := expander{config: config, lookup: envLookup(base)}
+	for _, w := range sc.assignments {
+		ew, err := baseExpander.expandVariables(w)
+		if err != nil {
+			return sc, err
+		}
+		expanded.assignments = append(expanded.assignments, ew)
+		env = append(env, ew.String())
+	}
+
+	x := expander{config: config, lookup: envLookup(mergeEnv(base, env))}
+	for _, w := range sc.words {
+		fields, err := x.expandFields(w)
+		if err != nil {
+			return sc, err
+		}
+		expanded.words = append(expanded.words, fields...)
+	}
+	for _, r := range sc.redirects {
+		if r.op != ">&" {
+			target, err := x.expandTarget(r.target)
+			if err != nil {
+				return sc, err
+			}
+			r.target = target
+		}
+		expanded.redirects = append(expanded.redirects, r)
+	}
+
+	return expanded, nil
+}
+
+// expander 하나의 명령어에 적용할 확장 설정과 환경변수
+type expander struct {
+	config config
+	lookup func(name string) (string, bool)
+}
+
+// expandWord 틸드와 변수를 확장합니다
+func (x expander) expandWord(w word) (word, error) {
+	if x.config.ExpandTilde {
+		w = expandTilde(w, x.home())
+	}
+	return x.expandVariables(w)
+}
+
+func (x expander) expandVariables(w word) (word, error) {
+	if !x.config.ExpandEnv {
+		return w, nil
+	}
+	return expandWord(w, x.lookup)
+}
+
+// expandFields 인수 하나를 확장하여 0개 이상의 인수로 만듭니다
+func (x expander) expandFields(w word) ([]word, error) {
+	ew, err := x.expandWord(w)
+	if err != nil {
+		return nil, err
+	}
+	if ew.String() == "" && !ew.quoted() {
+		// 인용부호 없이 빈 값으로 확장된 인수는 제거합니다 (예: "$UNSET"은 빈 인수로 유지)
+		return nil, nil
+	}
+	if x.config.Glob == GlobOff || ew.String() == "" {
+		return []word{ew}, nil
+	}
+	return expandGlobWord(ew, string(x.config.RunDir), x.config.Glob)
+}
+
+// expandTarget 리다이렉션 대상을 확장합니다 (glob은 정확히 하나의 파일과 일치해야 함)
+func (x expander) expandTarget(w word) (word, error) {
+	ew, err := x.expandWord(w)
+	if err != nil || x.config.Glob == GlobOff {
+		return ew, err
+	}
+	fields, err := expandGlobWord(ew, string(x.config.RunDir), x.config.Glob)
+	if err != nil {
+		return ew, err
+	}
+	if len(fields) != 1 {
+		return ew, fmt.Errorf("리다이렉션 대상이 모호합니다: %s", ew)
+	}
+	return fields[0], nil
+}
+
+// home 틸드 확장에 사용할 홈 디렉토리 (명령어 환경변수의 HOME 우선)
+func (x expander) home() string {
+	if home, ok := x.lookup("HOME"); ok && home != "" {
+		return home
+	}
+	home, _ := os.UserHomeDir()
+	return home
+}
+
+func envLookup(env []string) func(name string) (string, bool) {
+	values := make(map[string]string, len(env))
+	for _, kv := range env {
+		name, value, _ := strings.Cut(kv, "=")
+		values[name] = value
+	}
+	return func(name string) (string, bool) {
+		value, ok := values[name]
+		return value, ok
+	}
+}
+
+// expandWord 인용부호 밖과 큰따옴표 안의 변수를 확장합니다 (작은따옴표 안과 이스케이프된 문자는 그대로 유지)
+func expandWord(w word, lookup func(name string) (string, bool)) (word, error) {
+	expanded := make(word, 0, len(w))
+	for _, part := range w {
+		if part.quote == 0 || part.quote == '"' {
+			text, err := expandString(part.text, lookup)
+			if err != nil {
+				return w, err
+			}
+			part.text = text
+		}
+		expanded = append(expanded, part)
+	}
+	return expanded, nil
+}
+
+// expandString $VAR, ${VAR}, ${VAR:-default}, ${VAR:?error} 형식의 변수를 확장합니다
+// 예: expandString("${HOME}/bin", lookup) -> "/home/user/bin"
+func expandString(s string, lookup func(name string) (string, bool)) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 >= len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		if s[i+1] == '{' {
+			end := strings.IndexByte(s[i+2:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("닫히지 않은 변수 확장입니다: %s", s[i:])
+			}
+			value, err := expandBraced(s[i+2:i+2+end], lookup)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(value)
+			i += end + 2
+			continue
+		}
+
+		n := envNameLength(s[i+1:])
+		if n == 0 {
+			b.WriteByte(s[i])
+			continue
+		}
+		value, _ := lookup(s[i+1 : i+1+n])
+		b.WriteString(value)
+		i += n
+	}
+	return b.String(), nil
+}
+
+// expandBraced ${...} 안의 내용을 확장합니다
+func expandBraced(expr string, lookup func(name string) (string, bool)) (string, error) {
+	n := envNameLength(expr)
+	if n == 0 {
+		return "", fmt.Errorf("잘못된 변수 확장입니다: ${%s}", expr)
+	}
+	name, modifier := expr[:n], expr[n:]
+	value, _ := lookup(name)
+
+	switch {
+	case modifier == "":
+		return value, nil
+	case strings.HasPrefix(modifier, ":-"):
+		if value == "" {
+			return expandString(modifier[2:], lookup)
+		}
+		return value, nil
+	case strings.HasPrefix(modifier, ":?"):
+		if value == "" {
+			message := modifier[2:]
+			if message == "" {
+				message = "값이 없거나 설정되지 않았습니다"
+			}
+			return "", fmt.Errorf("환경변수 %s: %s", name, message)
+		}
+		return value, nil
+	}
+	return "", fmt.Errorf("잘못된 변수 확장입니다: ${%s}", expr)
+}
+
+// envNameLength s의 앞부분에서 환경변수 이름으로 사용할 수 있는 길이
+func envNameLength(s string) int {
+	n := 0
+	for n < len(s) && isEnvName(s[:n+1]) {
+		n++
+	}
+	return n
+}
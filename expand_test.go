@@ -0,0 +1,116 @@
+package easycmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandString(t *testing.T) {
+	lookup := envLookup([]string{"HOME=/home/user", "EMPTY=", "NAME=easycmd"})
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "변수 없음", input: "hello", expected: "hello"},
+		{name: "단순 변수", input: "$HOME", expected: "/home/user"},
+		{name: "중괄호 변수", input: "${HOME}/bin", expected: "/home/user/bin"},
+		{name: "이름 뒤 문자", input: "$NAME-v1", expected: "easycmd-v1"},
+		{name: "설정되지 않은 변수", input: "a${UNSET}b", expected: "ab"},
+		{name: "기본값 사용", input: "${UNSET:-default}", expected: "default"},
+		{name: "빈 값일 때 기본값", input: "${EMPTY:-default}", expected: "default"},
+		{name: "값이 있으면 기본값 무시", input: "${NAME:-default}", expected: "easycmd"},
+		{name: "기본값 안의 변수", input: "${UNSET:-$HOME}", expected: "/home/user"},
+		{name: "값이 있으면 에러 없음", input: "${NAME:?required}", expected: "easycmd"},
+		{name: "값이 없으면 에러", input: "${UNSET:?required}", wantErr: true},
+		{name: "변수 이름이 아닌 $", input: "cost $5 $", expected: "cost $5 $"},
+		{name: "닫히지 않은 중괄호", input: "${HOME", wantErr: true},
+		{name: "잘못된 확장", input: "${HOME:+x}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := expandString(tt.input, lookup)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expandString(%q) 에러 기대, 결과: %q", tt.input, result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expandString(%q) 에러: %v", tt.input, err)
+			}
+			if result != tt.expected {
+				t.Errorf("expandString(%q) = %q, 기대값: %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSimpleCommandExpandEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "인용부호 밖과 큰따옴표 안은 확장",
+			input:    `echo $A "$A b"`,
+			expected: []string{"echo", "1", "1 b"},
+		},
+		{
+			name:     "작은따옴표 안은 그대로",
+			input:    `echo '$A'`,
+			expected: []string{"echo", "$A"},
+		},
+		{
+			name:     "이스케이프된 $는 그대로",
+			input:    `echo \$A`,
+			expected: []string{"echo", "$A"},
+		},
+		{
+			name:     "명령어 앞의 환경변수 지정 반영",
+			input:    `A=2 echo $A`,
+			expected: []string{"echo", "2"},
+		},
+		{
+			name:     "빈 값으로 확장된 인수 제거",
+			input:    `echo $UNSET x`,
+			expected: []string{"echo", "x"},
+		},
+		{
+			name:     "인용부호로 감싼 빈 값은 유지",
+			input:    `printf [%s] "$UNSET" x`,
+			expected: []string{"printf", "[%s]", "", "x"},
+		},
+		{
+			name:     "인용부호 조각이 있으면 빈 값도 유지",
+			input:    `echo $UNSET'' x`,
+			expected: []string{"echo", "", "x"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, err := parseSimpleCommand(lexCommand(tt.input, true, operatorEscapable+"$"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expanded, err := sc.expand(config{ExpandEnv: true, Env: []string{"A=1"}})
+			if err != nil {
+				t.Fatalf("expand() 에러: %v", err)
+			}
+
+			var result []string
+			for _, w := range expanded.words {
+				result = append(result, w.String())
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expand(%q) = %q, 기대값: %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
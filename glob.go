@@ -0,0 +1,213 @@
+package easycmd
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// GlobMode glob 확장 방식
+type GlobMode int
+
+const (
+	GlobOff  GlobMode = iota // glob 확장을 하지 않음 (기본값)
+	GlobKeep                 // 일치하는 파일이 없으면 패턴을 그대로 인수로 전달 (bash 기본 동작)
+	GlobNull                 // 일치하는 파일이 없으면 인수에서 제거 (bash nullglob)
+	GlobFail                 // 일치하는 파일이 없으면 에러 반환 (bash failglob)
+)
+
+const globMetaChars = "*?["
+
+// expandGlobWord 인용부호 밖에 glob 문자가 있는 단어를 일치하는 파일 경로들로 확장합니다
+// 상대 경로 패턴은 dir을 기준으로 찾으며, 인용부호 안의 glob 문자는 일반 문자로 취급합니다
+func expandGlobWord(w word, dir string, mode GlobMode) ([]word, error) {
+	pattern, ok := globPattern(w)
+	if !ok {
+		return []word{w}, nil
+	}
+
+	matches := glob(pattern, dir)
+	if len(matches) == 0 {
+		switch mode {
+		case GlobNull:
+			return nil, nil
+		case GlobFail:
+			return nil, fmt.Errorf("일치하는 파일이 없습니다: %s", w)
+		default:
+			return []word{w}, nil
+		}
+	}
+
+	words := make([]word, len(matches))
+	for i, match := range matches {
+		words[i] = word{{text: match, quote: '\\'}}
+	}
+	return words, nil
+}
+
+// globPattern 단어를 glob 패턴으로 변환합니다
+// 인용부호 밖에 glob 문자가 없으면 false를 반환합니다
+func globPattern(w word) (string, bool) {
+	var b strings.Builder
+	hasMeta := false
+	for _, part := range w {
+		if part.quote == 0 {
+			hasMeta = hasMeta || strings.ContainsAny(part.text, globMetaChars)
+			b.WriteString(part.text)
+			continue
+		}
+		for _, r := range part.text {
+			if strings.ContainsRune(globMetaChars+`\`, r) {
+				b.WriteRune('\\')
+			}
+			b.WriteRune(r)
+		}
+	}
+	return b.String(), hasMeta
+}
+
+// glob pattern과 일치하는 경로를 정렬하여 반환합니다
+// filepath.Match 문법에 더해 **는 0개 이상의 디렉토리와 일치하며, .으로 시작하는 파일은 패턴도 .으로 시작할 때만 일치합니다
+func glob(pattern string, dir string) []string {
+	prefix := ""
+	if strings.HasPrefix(pattern, "/") {
+		prefix = "/"
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(pattern, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	var matches []string
+	globSegments(dir, prefix, segments, &matches)
+
+	sort.Strings(matches)
+	return matches
+}
+
+func globSegments(dir string, prefix string, segments []string, matches *[]string) {
+	if len(segments) == 0 {
+		if prefix != "" {
+			*matches = append(*matches, prefix)
+		}
+		return
+	}
+	segment, rest := segments[0], segments[1:]
+
+	if !strings.ContainsAny(segment, globMetaChars) {
+		next := path.Join(prefix, unescapeGlob(segment))
+		if _, err := os.Lstat(resolveGlobPath(dir, next)); err == nil {
+			globSegments(dir, next, rest, matches)
+		}
+		return
+	}
+
+	entries, err := os.ReadDir(resolveGlobPath(dir, prefix))
+	if err != nil {
+		return
+	}
+
+	if segment == "**" {
+		globSegments(dir, prefix, rest, matches)
+		for _, entry := range entries {
+			if isHiddenName(entry.Name()) {
+				continue
+			}
+			next := path.Join(prefix, entry.Name())
+			if isDirEntry(dir, next) {
+				globSegments(dir, next, segments, matches)
+			} else if len(rest) == 0 {
+				// bash globstar와 같이 마지막 **는 각 단계의 파일과도 일치합니다
+				*matches = append(*matches, next)
+			}
+		}
+		return
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if isHiddenName(name) && !strings.HasPrefix(segment, ".") {
+			continue
+		}
+		if ok, _ := filepath.Match(segment, name); !ok {
+			continue
+		}
+		next := path.Join(prefix, name)
+		if len(rest) > 0 && !isDirEntry(dir, next) {
+			continue
+		}
+		globSegments(dir, next, rest, matches)
+	}
+}
+
+// resolveGlobPath 패턴 기준의 경로를 실제 파일 시스템 경로로 변환
+func resolveGlobPath(dir string, p string) string {
+	if p == "" {
+		p = "."
+	}
+	if filepath.IsAbs(p) || dir == "" {
+		return filepath.FromSlash(p)
+	}
+	return filepath.Join(dir, filepath.FromSlash(p))
+}
+
+func isDirEntry(dir string, p string) bool {
+	info, err := os.Stat(resolveGlobPath(dir, p))
+	return err == nil && info.IsDir()
+}
+
+func isHiddenName(name string) bool {
+	return strings.HasPrefix(name, ".")
+}
+
+// unescapeGlob 백슬래시로 이스케이프된 glob 문자를 일반 문자로 되돌립니다
+func unescapeGlob(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// expandTilde 인용부호 밖의 단어 맨 앞 ~, ~/..., ~user/...를 홈 디렉토리로 확장합니다
+// 알 수 없는 사용자이거나 홈 디렉토리를 알 수 없으면 그대로 둡니다
+func expandTilde(w word, home string) word {
+	if len(w) == 0 || w[0].quote != 0 || !strings.HasPrefix(w[0].text, "~") {
+		return w
+	}
+	name, rest, _ := strings.Cut(w[0].text[1:], "/")
+	if rest != "" || strings.Contains(w[0].text, "/") {
+		rest = "/" + rest
+	}
+	// ~user 뒤에 인용부호 부분이 이어지면 사용자 이름이 끝나지 않은 것으로 봄 (예: ~"user")
+	if rest == "" && len(w) > 1 {
+		return w
+	}
+
+	if name != "" {
+		u, err := user.Lookup(name)
+		if err != nil {
+			return w
+		}
+		home = u.HomeDir
+	}
+	if home == "" {
+		return w
+	}
+
+	expanded := word{{text: home, quote: '\\'}}
+	if rest != "" {
+		expanded = append(expanded, wordPart{text: rest})
+	}
+	return append(expanded, w[1:]...)
+}
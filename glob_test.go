@@ -0,0 +1,106 @@
+package easycmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGlob(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"a.go", "b.go", "c.txt", ".hidden.go", "sub/d.go", "sub/deep/e.go", "x[1].txt"} {
+		path := filepath.Join(dir, f)
+		os.MkdirAll(filepath.Dir(path), 0o755)
+		os.WriteFile(path, nil, 0o644)
+	}
+
+	tests := []struct {
+		name     string
+		pattern  string
+		expected []string
+	}{
+		{name: "별표", pattern: "*.go", expected: []string{"a.go", "b.go"}},
+		{name: "물음표", pattern: "?.txt", expected: []string{"c.txt"}},
+		{name: "문자 집합", pattern: "[ab].go", expected: []string{"a.go", "b.go"}},
+		{name: "숨김 파일", pattern: ".*.go", expected: []string{".hidden.go"}},
+		{name: "하위 디렉토리", pattern: "*/*.go", expected: []string{"sub/d.go"}},
+		{name: "재귀", pattern: "**/*.go", expected: []string{"a.go", "b.go", "sub/d.go", "sub/deep/e.go"}},
+		{name: "중간 재귀", pattern: "sub/**/*.go", expected: []string{"sub/d.go", "sub/deep/e.go"}},
+		{name: "마지막 재귀는 파일 포함", pattern: "**", expected: []string{"a.go", "b.go", "c.txt", "sub", "sub/d.go", "sub/deep", "sub/deep/e.go", "x[1].txt"}},
+		{name: "하위 디렉토리의 마지막 재귀", pattern: "sub/**", expected: []string{"sub", "sub/d.go", "sub/deep", "sub/deep/e.go"}},
+		{name: "이스케이프된 glob 문자", pattern: `x\[1\].*`, expected: []string{"x[1].txt"}},
+		{name: "일치 없음", pattern: "*.rs", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := glob(tt.pattern, dir)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("glob(%q) = %q, 기대값: %q", tt.pattern, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExpandGlobWord(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "a.o"), nil, 0o644)
+
+	tests := []struct {
+		name     string
+		word     word
+		mode     GlobMode
+		expected []string
+		wantErr  bool
+	}{
+		{name: "일치", word: word{{text: "*.o"}}, mode: GlobKeep, expected: []string{"a.o"}},
+		{name: "인용부호 안은 확장하지 않음", word: word{{text: "*.o", quote: '"'}}, mode: GlobKeep, expected: []string{"*.o"}},
+		{name: "일치 없으면 유지", word: word{{text: "*.c"}}, mode: GlobKeep, expected: []string{"*.c"}},
+		{name: "nullglob", word: word{{text: "*.c"}}, mode: GlobNull, expected: nil},
+		{name: "failglob", word: word{{text: "*.c"}}, mode: GlobFail, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			words, err := expandGlobWord(tt.word, dir, tt.mode)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("에러 기대, 결과: nil")
+				}
+				return
+			}
+
+			var result []string
+			for _, w := range words {
+				result = append(result, w.String())
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expandGlobWord() = %q, 기대값: %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExpandTilde(t *testing.T) {
+	tests := []struct {
+		name     string
+		word     word
+		expected string
+	}{
+		{name: "틸드만", word: word{{text: "~"}}, expected: "/home/me"},
+		{name: "하위 경로", word: word{{text: "~/projects"}}, expected: "/home/me/projects"},
+		{name: "인용부호 안은 그대로", word: word{{text: "~/projects", quote: '\''}}, expected: "~/projects"},
+		{name: "중간의 틸드는 그대로", word: word{{text: "a~/b"}}, expected: "a~/b"},
+		{name: "알 수 없는 사용자", word: word{{text: "~nosuchuser12345/x"}}, expected: "~nosuchuser12345/x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := expandTilde(tt.word, "/home/me").String()
+			if result != tt.expected {
+				t.Errorf("expandTilde(%q) = %q, 기대값: %q", tt.word.String(), result, tt.expected)
+			}
+		})
+	}
+}
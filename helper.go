@@ -0,0 +1,21 @@
+package easycmd
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// helperEnabled 현재 프로그램에서 HelperMain을 호출했는지 여부
+var helperEnabled atomic.Bool
+
+// HelperMain 명령어를 실행하기 직전에 적용해야 하는 설정(WithResourceLimits, WithNice, WithIOPriority, WithSandbox)을 사용할 수 있도록 합니다
+// 이런 설정은 현재 프로그램을 다시 실행하여 설정을 적용한 뒤 명령어로 교체하는 방식으로 처리하므로,
+// 다시 실행된 경우에는 설정을 적용하고 명령어를 실행하며 반환하지 않습니다
+// 그 외에는 바로 반환하므로 main 함수(테스트는 TestMain)의 맨 앞에서 호출해야 합니다
+// 예: func main() { easycmd.HelperMain(); ... }
+func HelperMain() {
+	runHelper()
+	helperEnabled.Store(true)
+}
+
+var HelperMainRequiredError = errors.New("easycmd.HelperMain() must be called at the start of main")
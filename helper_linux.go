@@ -0,0 +1,140 @@
+//go:build linux
+
+package easycmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+	"syscall"
+)
+
+// helperEnv 다시 실행한 현재 프로그램에 적용할 설정을 전달하는 환경변수
+const helperEnv = "EASYCMD_HELPER"
+
+// helperStatusFd 설정 실패 메시지를 부모에게 전달하는 파일 디스크립터 (ExtraFiles[0])
+const helperStatusFd = 3
+
+// helperSpec 다시 실행한 현재 프로그램이 설정을 적용한 뒤 실행할 명령어
+type helperSpec struct {
+	Path       string
+	Limits     []helperLimit
+	Nice       *int
+	IOPriority *IOPriority
+	Sandbox    *Sandbox
+}
+
+// helperLimit setrlimit(2)로 적용할 리소스 제한
+type helperLimit struct {
+	Name     string // 에러 메시지에 표시할 제한 (예: OpenFiles=64)
+	Resource int
+	Cur, Max uint64
+}
+
+// needsHelper 명령어를 실행하기 직전에 적용해야 하는 설정이 있는지 확인합니다
+// 우선순위는 HelperMain을 호출한 경우에만 실행 전에 적용하고, 그 외에는 시작한 직후 적용합니다
+func needsHelper(req Request) bool {
+	return req.Sandbox != nil || len(req.ResourceLimits) > 0 || (hasPriority(req) && helperEnabled.Load())
+}
+
+func newHelperSpec(path string, req Request) helperSpec {
+	s := helperSpec{Path: path, Nice: req.Nice, IOPriority: req.IOPriority, Sandbox: req.Sandbox}
+	for _, l := range req.ResourceLimits {
+		rlim := l.rlimit()
+		s.Limits = append(s.Limits, helperLimit{Name: l.String(), Resource: l.resource(), Cur: rlim.Cur, Max: rlim.Max})
+	}
+	return s
+}
+
+// runHelper 다시 실행된 경우 설정을 적용하고 원래 명령어로 교체합니다 (실패하면 부모에게 에러를 전달하고 종료)
+func runHelper() {
+	spec, ok := os.LookupEnv(helperEnv)
+	if !ok {
+		return
+	}
+	err := helperExec(spec)
+	status := os.NewFile(helperStatusFd, "helper-status")
+	fmt.Fprint(status, err)
+	os.Exit(127)
+}
+
+// helperExec 샌드박스, 우선순위, 리소스 제한을 적용한 뒤 원래 명령어를 실행합니다 (성공하면 반환하지 않음)
+// nice 값과 I/O 우선순위는 스레드 단위로 적용되므로 exec할 스레드를 고정한 뒤 적용합니다
+func helperExec(spec string) error {
+	var s helperSpec
+	if err := json.Unmarshal([]byte(spec), &s); err != nil {
+		return err
+	}
+	runtime.LockOSThread()
+	if s.Sandbox != nil {
+		if err := sandboxSetup(*s.Sandbox); err != nil {
+			return fmt.Errorf("샌드박스를 설정할 수 없습니다: %w", err)
+		}
+	}
+
+	env := slices.DeleteFunc(os.Environ(), func(kv string) bool {
+		return strings.HasPrefix(kv, helperEnv+"=")
+	})
+	syscall.CloseOnExec(helperStatusFd)
+	if err := applyPriority(0, Request{Nice: s.Nice, IOPriority: s.IOPriority}); err != nil {
+		return err
+	}
+	// 리소스 제한은 이 프로그램에도 적용되므로 exec 바로 전에 적용합니다
+	for _, l := range s.Limits {
+		if err := setrlimit(l.Resource, syscall.Rlimit{Cur: l.Cur, Max: l.Max}); err != nil {
+			return fmt.Errorf("리소스 제한을 적용할 수 없습니다 (%s): %w", l.Name, err)
+		}
+	}
+	return syscall.Exec(s.Path, os.Args, env)
+}
+
+// startHelper 현재 프로그램을 다시 실행하여 설정을 적용한 뒤 cmd의 명령어로 교체합니다
+// 원래 명령어가 실행되거나 설정에 실패할 때까지 대기하며, HelperMain을 호출하지 않았으면 HelperMainRequiredError를 반환합니다
+func startHelper(cmd *exec.Cmd, req Request) error {
+	if !helperEnabled.Load() {
+		return HelperMainRequiredError
+	}
+	if cmd.Err != nil {
+		return cmd.Err
+	}
+	spec, err := json.Marshal(newHelperSpec(cmd.Path, req))
+	if err != nil {
+		return err
+	}
+	status, statusWriter, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer status.Close()
+
+	env := cmd.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	cmd.Path = "/proc/self/exe"
+	cmd.Env = append(slices.Clip(env), helperEnv+"="+string(spec))
+	cmd.ExtraFiles = []*os.File{statusWriter}
+	if req.Sandbox != nil {
+		cmd.SysProcAttr = sandboxAttr(cmd.SysProcAttr, *req.Sandbox)
+	}
+
+	err = cmd.Start()
+	statusWriter.Close()
+	if err != nil && req.Sandbox != nil {
+		return fmt.Errorf("샌드박스를 시작할 수 없습니다: %w", err)
+	}
+	if err != nil {
+		return err
+	}
+	msg, _ := io.ReadAll(status)
+	if len(msg) > 0 {
+		cmd.Wait()
+		return fmt.Errorf("명령어 실행을 준비할 수 없습니다: %s", msg)
+	}
+	return nil
+}
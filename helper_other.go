@@ -0,0 +1,19 @@
+//go:build !linux
+
+package easycmd
+
+import (
+	"errors"
+	"os/exec"
+)
+
+// needsHelper 샌드박스는 Linux에서만 지원하므로 startHelper가 에러를 반환하도록 합니다
+func needsHelper(req Request) bool {
+	return req.Sandbox != nil
+}
+
+func runHelper() {}
+
+func startHelper(cmd *exec.Cmd, req Request) error {
+	return errors.New("샌드박스는 Linux에서만 지원합니다")
+}
@@ -0,0 +1,32 @@
+package easycmd
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+)
+
+func TestStartHelperRequiresHelperMain(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("리소스 제한과 샌드박스는 Linux에서만 지원")
+	}
+	tests := []struct {
+		name string
+		req  Request
+	}{
+		{name: "리소스 제한", req: Request{Name: "true", ResourceLimits: []ResourceLimit{LimitOpenFiles(64)}}},
+		{name: "샌드박스", req: Request{Name: "true", Sandbox: &Sandbox{}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 이 테스트 바이너리는 HelperMain을 호출하지 않음
+			_, err := ExecExecutor{}.Start(context.Background(), tt.req)
+
+			if !errors.Is(err, HelperMainRequiredError) {
+				t.Errorf("HelperMainRequiredError 기대, 결과: %v", err)
+			}
+		})
+	}
+}
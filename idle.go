@@ -0,0 +1,61 @@
+package easycmd
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// idleWatcher 출력이 timeout 동안 없으면 cancel을 호출하는 감시자
+type idleWatcher struct {
+	mu       sync.Mutex
+	timer    *time.Timer
+	timeout  time.Duration
+	exceeded atomic.Bool
+}
+
+func newIdleWatcher(timeout time.Duration, cancel context.CancelFunc) *idleWatcher {
+	w := &idleWatcher{timeout: timeout}
+	w.timer = time.AfterFunc(timeout, func() {
+		w.exceeded.Store(true)
+		cancel()
+	})
+	return w
+}
+
+// wrap 쓰기가 발생할 때마다 감시 타이머를 초기화하는 Writer로 감쌉니다
+func (w *idleWatcher) wrap(out io.Writer) io.Writer {
+	return &idleWriter{watcher: w, out: out}
+}
+
+func (w *idleWatcher) touch() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.exceeded.Load() {
+		w.timer.Reset(w.timeout)
+	}
+}
+
+func (w *idleWatcher) stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.timer.Stop()
+}
+
+func (w *idleWatcher) isExceeded() bool {
+	return w.exceeded.Load()
+}
+
+type idleWriter struct {
+	watcher *idleWatcher
+	out     io.Writer
+}
+
+func (i *idleWriter) Write(p []byte) (int, error) {
+	if len(p) > 0 {
+		i.watcher.touch()
+	}
+	return i.out.Write(p)
+}
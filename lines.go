@@ -0,0 +1,75 @@
+package easycmd
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+const defaultMaxLineLength = 64 * 1024
+
+// lineWriter 받은 바이트를 줄 단위로 나누어 handler에 전달하는 Writer
+// 줄바꿈(\n, \r\n)은 제거되며, maxLength를 넘는 줄은 maxLength 단위로 잘라서 전달합니다
+type lineWriter struct {
+	handler   func(line string)
+	maxLength int
+	buf       []byte
+}
+
+func newLineWriter(handler func(line string), maxLength int) *lineWriter {
+	if maxLength <= 0 {
+		maxLength = defaultMaxLineLength
+	}
+	return &lineWriter{handler: handler, maxLength: maxLength}
+}
+
+func (l *lineWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			l.buf = append(l.buf, p...)
+			l.splitLongLine()
+			break
+		}
+		l.buf = append(l.buf, p[:i]...)
+		l.splitLongLine()
+		l.emit(l.buf)
+		l.buf = l.buf[:0]
+		p = p[i+1:]
+	}
+	return n, nil
+}
+
+// Flush 줄바꿈 없이 끝난 마지막 줄을 전달합니다
+func (l *lineWriter) Flush() {
+	if l != nil && len(l.buf) > 0 {
+		l.emit(l.buf)
+		l.buf = l.buf[:0]
+	}
+}
+
+// splitLongLine 버퍼가 maxLength를 넘으면 UTF-8 문자가 깨지지 않는 위치에서 잘라 전달
+func (l *lineWriter) splitLongLine() {
+	for len(l.buf) > l.maxLength {
+		cut := l.maxLength
+		for cut > 0 && !utf8.RuneStart(l.buf[cut]) {
+			cut--
+		}
+		if cut == 0 {
+			cut = l.maxLength
+		}
+		l.handler(string(l.buf[:cut]))
+		l.buf = append(l.buf[:0], l.buf[cut:]...)
+	}
+}
+
+func (l *lineWriter) emit(line []byte) {
+	l.handler(string(bytes.TrimSuffix(line, []byte{'\r'})))
+}
+
+// chanLineHandler 각 줄을 채널로 보내는 handler를 만듭니다
+func chanLineHandler(ch chan<- string) func(line string) {
+	return func(line string) {
+		ch <- line
+	}
+}
@@ -0,0 +1,77 @@
+package easycmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLineWriter(t *testing.T) {
+	tests := []struct {
+		name      string
+		writes    []string
+		maxLength int
+		expected  []string
+	}{
+		{
+			name:     "한 번에 여러 줄",
+			writes:   []string{"a\nb\nc\n"},
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "여러 번에 나뉜 줄",
+			writes:   []string{"hel", "lo\nwor", "ld\n"},
+			expected: []string{"hello", "world"},
+		},
+		{
+			name:     "CRLF 줄바꿈",
+			writes:   []string{"a\r\nb\r", "\n"},
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "빈 줄 유지",
+			writes:   []string{"a\n\nb\n"},
+			expected: []string{"a", "", "b"},
+		},
+		{
+			name:     "줄바꿈 없는 마지막 줄",
+			writes:   []string{"a\nlast"},
+			expected: []string{"a", "last"},
+		},
+		{
+			name:      "최대 길이를 넘는 줄",
+			writes:    []string{"abcdefg\n"},
+			maxLength: 3,
+			expected:  []string{"abc", "def", "g"},
+		},
+		{
+			name:      "최대 길이와 같은 줄",
+			writes:    []string{"abc\n"},
+			maxLength: 3,
+			expected:  []string{"abc"},
+		},
+		{
+			name:      "멀티바이트 문자는 자르지 않음",
+			writes:    []string{"가나다\n"},
+			maxLength: 4,
+			expected:  []string{"가", "나", "다"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result []string
+			lw := newLineWriter(func(line string) {
+				result = append(result, line)
+			}, tt.maxLength)
+
+			for _, w := range tt.writes {
+				lw.Write([]byte(w))
+			}
+			lw.Flush()
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("lines = %q, 기대값: %q", result, tt.expected)
+			}
+		})
+	}
+}
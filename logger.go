@@ -13,9 +13,16 @@ type Logger interface {
 	ExecutionDirectory(dir string)
 	ExecutionStart()
 	Timeout(timeout time.Duration)
+	IdleTimeout(timeout time.Duration)
 	Environment(envCount int)
+	Credential(cred Credential)
+	Sandbox(sandbox Sandbox)
+	EnvironmentOverride(assignments []string)
 	StartFailed(err error)
 	ExecutionFailed(err error, isTimeout bool)
+	IdleTimeoutExceeded(timeout time.Duration)
+	PolicyViolated(violation *PolicyViolation)
+	ResourceLimitExceeded(limit ResourceLimit)
 	ExecutionCompleted()
 }
 
@@ -54,10 +61,26 @@ func (d *DebugLogger) Timeout(timeout time.Duration) {
 	fmt.Fprintf(d.out, "[DEBUG] 타임아웃 설정: %s\n", timeout)
 }
 
+func (d *DebugLogger) IdleTimeout(timeout time.Duration) {
+	fmt.Fprintf(d.out, "[DEBUG] 유휴 타임아웃 설정: %s\n", timeout)
+}
+
 func (d *DebugLogger) Environment(envCount int) {
 	fmt.Fprintf(d.out, "[DEBUG] 환경변수 설정: %d개\n", envCount)
 }
 
+func (d *DebugLogger) Credential(cred Credential) {
+	fmt.Fprintf(d.out, "[DEBUG] 실행 사용자: %s\n", cred)
+}
+
+func (d *DebugLogger) Sandbox(sandbox Sandbox) {
+	fmt.Fprintf(d.out, "[DEBUG] 샌드박스: %s\n", sandbox)
+}
+
+func (d *DebugLogger) EnvironmentOverride(assignments []string) {
+	fmt.Fprintf(d.out, "[DEBUG] 명령어 환경변수 지정: %v\n", assignments)
+}
+
 func (d *DebugLogger) StartFailed(err error) {
 	fmt.Fprintf(d.out, "[DEBUG] 명령어 시작 실패: %s\n", err)
 }
@@ -70,6 +93,18 @@ func (d *DebugLogger) ExecutionFailed(err error, isTimeout bool) {
 	}
 }
 
+func (d *DebugLogger) IdleTimeoutExceeded(timeout time.Duration) {
+	fmt.Fprintf(d.out, "[DEBUG] 명령어 유휴 타임아웃: %s 동안 출력 없음\n", timeout)
+}
+
+func (d *DebugLogger) PolicyViolated(violation *PolicyViolation) {
+	fmt.Fprintf(d.out, "[DEBUG] 정책 위반: %s\n", violation)
+}
+
+func (d *DebugLogger) ResourceLimitExceeded(limit ResourceLimit) {
+	fmt.Fprintf(d.out, "[DEBUG] 리소스 제한 초과: %s\n", limit)
+}
+
 func (d *DebugLogger) ExecutionCompleted() {
 	actualDuration := time.Since(d.startTime)
 	fmt.Fprintf(d.out, "[DEBUG] 명령어 실행 완료 (실행 시간: %s)\n", actualDuration)
@@ -88,7 +123,14 @@ func (n *NoOpLogger) ExecutionCommand(name string, args []string) {}
 func (n *NoOpLogger) ExecutionDirectory(dir string)               {}
 func (n *NoOpLogger) ExecutionStart()                             {}
 func (n *NoOpLogger) Timeout(timeout time.Duration)               {}
+func (n *NoOpLogger) IdleTimeout(timeout time.Duration)           {}
 func (n *NoOpLogger) Environment(envCount int)                    {}
+func (n *NoOpLogger) Credential(cred Credential)                  {}
+func (n *NoOpLogger) Sandbox(sandbox Sandbox)                     {}
+func (n *NoOpLogger) EnvironmentOverride(assignments []string)    {}
 func (n *NoOpLogger) StartFailed(err error)                       {}
 func (n *NoOpLogger) ExecutionFailed(err error, isTimeout bool)   {}
+func (n *NoOpLogger) IdleTimeoutExceeded(timeout time.Duration)   {}
+func (n *NoOpLogger) PolicyViolated(violation *PolicyViolation)   {}
+func (n *NoOpLogger) ResourceLimitExceeded(limit ResourceLimit)   {}
 func (n *NoOpLogger) ExecutionCompleted()                         {}
@@ -0,0 +1,86 @@
+package easycmd
+
+import (
+	"io"
+	"sync"
+)
+
+// outputWriters 설정에 따라 명령어의 stdout과 stderr로 사용할 Writer를 구성합니다
+// stdout과 stderr이 같은 Writer로 설정되었지만 구성된 Writer가 다르면 두 Writer의 쓰기를 직렬화합니다
+// 반환된 flush는 프로세스 종료 후 호출해 줄바꿈 없이 끝난 마지막 줄을 전달합니다
+func (c config) outputWriters() (io.Writer, io.Writer, func()) {
+	stdOut, flushStdOut := c.outputWriter(c.StdOut, c.StdOutLineHandler)
+	stdErr, flushStdErr := c.outputWriter(c.StdErr, c.StdErrLineHandler)
+	flush := func() {
+		flushStdOut()
+		flushStdErr()
+	}
+
+	if sameWriter(c.StdOut, c.StdErr) && !sameWriter(stdOut, stdErr) {
+		mu := &sync.Mutex{}
+		stdOut = &syncWriter{mu: mu, w: stdOut}
+		stdErr = &syncWriter{mu: mu, w: stdErr}
+	}
+	return stdOut, stdErr, flush
+}
+
+func (c config) outputWriter(out io.Writer, handler func(line string)) (io.Writer, func()) {
+	var writers []io.Writer
+	var lineWriters []*lineWriter
+
+	if out != nil {
+		if c.OutputPrefix != "" {
+			pw := newPrefixWriter(out, c.OutputPrefix, c.OutputColor, c.MaxLineLength)
+			lineWriters = append(lineWriters, pw)
+			out = pw
+		}
+		writers = append(writers, out)
+	}
+	if handler != nil {
+		lw := newLineWriter(handler, c.MaxLineLength)
+		lineWriters = append(lineWriters, lw)
+		writers = append(writers, lw)
+	}
+
+	flush := func() {
+		for _, lw := range lineWriters {
+			lw.Flush()
+		}
+	}
+
+	if len(writers) == 1 {
+		return writers[0], flush
+	}
+	return io.MultiWriter(writers...), flush
+}
+
+// sameWriter os/exec과 같이 두 Writer가 같은 값인지 비교합니다
+// 비교할 수 없는 타입은 서로 다른 Writer로 봅니다
+func sameWriter(a, b io.Writer) (same bool) {
+	if a == nil || b == nil {
+		return false
+	}
+	defer func() {
+		if recover() != nil {
+			same = false
+		}
+	}()
+	return a == b
+}
+
+// syncWriter 여러 고루틴이 동시에 쓰는 Writer를 보호합니다
+// 같은 대상에 쓰는 여러 syncWriter는 mu를 공유합니다
+type syncWriter struct {
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+func newSyncWriter(w io.Writer) *syncWriter {
+	return &syncWriter{mu: &sync.Mutex{}, w: w}
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
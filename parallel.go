@@ -0,0 +1,253 @@
+package easycmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Spec 병렬 실행할 명령어 정의
+type Spec struct {
+	Command string
+	Dir     string // 비어 있으면 Cmd의 실행 디렉토리를 사용
+	Shell   bool   // true이면 RunShell과 같이 bash로 래핑하여 실행
+}
+
+func (s Spec) command() command {
+	if s.Shell {
+		return command(s.Command).ShellCommand()
+	}
+	return command(s.Command)
+}
+
+// ParallelOptions 병렬 실행 옵션
+type ParallelOptions struct {
+	MaxConcurrency int  // 동시에 실행할 최대 명령어 수 (0 이하이면 제한 없음)
+	FailFast       bool // true이면 첫 실패 시 실행 중인 나머지 명령어를 종료하고 대기 중인 명령어는 실행하지 않음
+}
+
+// SpecError 병렬 실행 중 실패한 명령어의 에러
+type SpecError struct {
+	Spec Spec
+	Err  error
+}
+
+func (e *SpecError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Spec.Command, e.Err)
+}
+
+func (e *SpecError) Unwrap() error {
+	return e.Err
+}
+
+// ParallelError 병렬 실행 중 실패한 명령어들의 에러 모음
+type ParallelError struct {
+	Errors []*SpecError
+}
+
+func (e *ParallelError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "병렬 실행 중 %d개 명령어가 실패했습니다", len(e.Errors))
+	for _, err := range e.Errors {
+		fmt.Fprintf(&b, "\n- %s", err)
+	}
+	return b.String()
+}
+
+func (e *ParallelError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Group 명령어를 병렬로 실행하고 결과를 모으는 실행 그룹
+// 각 명령어의 출력, 줄 단위 handler 호출, 디버그 출력은 완료될 때까지 버퍼에 모았다가 명령어 단위로 출력합니다
+type Group struct {
+	c      config
+	opts   ParallelOptions
+	parent context.Context
+	ctx    context.Context
+	cancel context.CancelFunc
+	sem    chan struct{}
+	wg     sync.WaitGroup
+
+	mu    sync.Mutex
+	count int
+	errs  []indexedSpecError
+
+	outMu sync.Mutex
+}
+
+type indexedSpecError struct {
+	index int
+	err   *SpecError
+}
+
+// NewGroup 병렬 실행 그룹을 생성합니다
+func (c *Cmd) NewGroup(ctx context.Context, opts ParallelOptions) *Group {
+	groupCtx, cancel := context.WithCancel(ctx)
+	g := &Group{
+		c:      c.c,
+		opts:   opts,
+		parent: ctx,
+		ctx:    groupCtx,
+		cancel: cancel,
+	}
+	if opts.MaxConcurrency > 0 {
+		g.sem = make(chan struct{}, opts.MaxConcurrency)
+	}
+	return g
+}
+
+// Go 명령어를 그룹에 추가하여 실행합니다
+func (g *Group) Go(spec Spec) {
+	g.mu.Lock()
+	index := g.count
+	g.count++
+	g.mu.Unlock()
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+
+		if g.sem != nil {
+			select {
+			case g.sem <- struct{}{}:
+				defer func() { <-g.sem }()
+			case <-g.ctx.Done():
+				return
+			}
+		}
+		if g.ctx.Err() != nil {
+			return
+		}
+
+		if err := g.run(spec); err != nil {
+			g.fail(index, spec, err)
+		}
+	}()
+}
+
+// Wait 모든 명령어가 끝날 때까지 대기하고, 실패한 명령어가 있으면 *ParallelError를 반환합니다
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+
+	var errs []*SpecError
+	sort.Slice(g.errs, func(i, j int) bool {
+		return g.errs[i].index < g.errs[j].index
+	})
+	for _, e := range g.errs {
+		errs = append(errs, e.err)
+	}
+
+	if len(errs) > 0 {
+		return &ParallelError{Errors: errs}
+	}
+	return g.parent.Err()
+}
+
+func (g *Group) run(spec Spec) error {
+	config := g.c
+	if spec.Dir != "" {
+		config.RunDir = runDir(spec.Dir)
+	}
+
+	stdOut := &bytes.Buffer{}
+	stdErr := &bytes.Buffer{}
+	if config.StdOut != nil {
+		config.StdOut = stdOut
+	}
+	if config.StdErr != nil {
+		config.StdErr = stdErr
+	}
+	lines := &lineBuffer{}
+	if config.StdOutLineHandler != nil {
+		config.StdOutLineHandler = lines.handler(g.c.StdOutLineHandler)
+	}
+	if config.StdErrLineHandler != nil {
+		config.StdErrLineHandler = lines.handler(g.c.StdErrLineHandler)
+	}
+	// DebugLogger는 실행 시작 시각을 기록하므로 명령어마다 별도의 로거를 사용합니다
+	debugOut := &bytes.Buffer{}
+	debugLogger, debug := g.c.Logger.(*DebugLogger)
+	if debug {
+		config.Logger = NewDebugLogger(debugOut)
+	}
+
+	err := run(g.ctx, spec.command(), config)
+
+	g.outMu.Lock()
+	defer g.outMu.Unlock()
+	if debug {
+		debugLogger.out.Write(debugOut.Bytes())
+	}
+	if g.c.StdOut != nil {
+		g.c.StdOut.Write(stdOut.Bytes())
+	}
+	if g.c.StdErr != nil {
+		g.c.StdErr.Write(stdErr.Bytes())
+	}
+	lines.replay()
+
+	return err
+}
+
+// lineBuffer 명령어가 끝날 때까지 줄 단위 handler 호출을 모아두는 버퍼
+type lineBuffer struct {
+	mu    sync.Mutex
+	lines []bufferedLine
+}
+
+type bufferedLine struct {
+	handler func(line string)
+	line    string
+}
+
+// handler 줄을 바로 전달하지 않고 버퍼에 모으는 handler를 만듭니다
+func (b *lineBuffer) handler(handler func(line string)) func(line string) {
+	return func(line string) {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.lines = append(b.lines, bufferedLine{handler: handler, line: line})
+	}
+}
+
+// replay 모아둔 줄을 받은 순서대로 원래 handler에 전달합니다
+func (b *lineBuffer) replay() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, l := range b.lines {
+		l.handler(l.line)
+	}
+}
+
+func (g *Group) fail(index int, spec Spec, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// fail-fast로 그룹이 취소되어 종료된 명령어는 실패로 집계하지 않음
+	if errors.Is(err, context.Canceled) && g.parent.Err() == nil {
+		return
+	}
+
+	g.errs = append(g.errs, indexedSpecError{index: index, err: &SpecError{Spec: spec, Err: err}})
+	if g.opts.FailFast {
+		g.cancel()
+	}
+}
+
+// RunParallel specs를 병렬로 실행하고 모든 명령어가 끝날 때까지 대기합니다
+func (c *Cmd) RunParallel(ctx context.Context, specs []Spec, opts ParallelOptions) error {
+	g := c.NewGroup(ctx, opts)
+	for _, spec := range specs {
+		g.Go(spec)
+	}
+	return g.Wait()
+}
@@ -1,35 +1,168 @@
 package easycmd
 
-import "strings"
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// token 명령어 문자열을 나눈 단위 (단어 또는 연산자)
+type token struct {
+	op    string // 연산자 토큰이면 연산자 문자열 (예: "<", "&&", "2>&1"), 단어 토큰이면 빈 문자열
+	word  word
+	start int // 원본 문자열에서의 시작 위치 (rune 단위)
+	end   int
+}
+
+// word 인용부호 정보를 유지한 단어
+// 예: a'b c'"d" -> [{a 0} {b c '} {d "}]
+type word []wordPart
+
+type wordPart struct {
+	text  string
+	quote rune // 0: 인용부호 밖, '\'', '"': 인용부호 안, '\\': 백슬래시로 이스케이프된 문자
+}
+
+func (w word) String() string {
+	var b strings.Builder
+	for _, part := range w {
+		b.WriteString(part.text)
+	}
+	return b.String()
+}
+
+// quoted 인용부호로 감싼 조각이 있는지 확인
+func (w word) quoted() bool {
+	for _, part := range w {
+		if part.quote == '\'' || part.quote == '"' {
+			return true
+		}
+	}
+	return false
+}
 
 // parseCommandArgs 명령어 문자열을 인수 배열로 파싱 (인용부호 처리 포함)
 func parseCommandArgs(cmd string) []string {
 	var args []string
-	var currentToken strings.Builder
-	var insideQuotes bool
-	var activeQuoteChar rune
+	for _, t := range lexCommand(cmd, false, "") {
+		args = append(args, t.word.String())
+	}
+	return args
+}
+
+// lexCommand 명령어 문자열을 단어와 연산자 토큰으로 나눕니다
+// operators가 false이면 연산자를 인식하지 않고 모든 문자를 단어로 취급합니다
+// 백슬래시는 escapable에 포함된 문자 앞에서만 이스케이프로 인식합니다
+func lexCommand(cmd string, operators bool, escapable string) []token {
+	l := &lexer{}
+	runes := []rune(cmd)
+
+	for i := 0; i < len(runes); i++ {
+		char := runes[i]
+		if l.quote != 0 {
+			if char == l.quote {
+				// 인용부호 종료
+				l.endPart()
+			} else {
+				l.current.WriteRune(char)
+			}
+			continue
+		}
+
+		op, n := "", 0
+		if operators && isOperatorStart(char) {
+			op, n = readOperator(runes[i:])
+		}
 
-	for _, char := range cmd {
-		if !insideQuotes && isQuoteChar(char) {
+		switch {
+		case isQuoteChar(char):
 			// 인용부호 시작
-			insideQuotes = true
-			activeQuoteChar = char
-		} else if insideQuotes && char == activeQuoteChar {
-			// 인용부호 종료
-			insideQuotes = false
-		} else if !insideQuotes && char == ' ' {
+			l.beginWord(i)
+			l.endPart()
+			l.quote = char
+		case char == ' ':
 			// 공백으로 토큰 분리
-			args = addTokenIfNotEmpty(args, currentToken.String())
-			currentToken.Reset()
-		} else {
+			l.endWord(i)
+		case char == '\\' && i+1 < len(runes) && strings.ContainsRune(escapable, runes[i+1]):
+			// 특수 문자 이스케이프
+			l.beginWord(i)
+			i++
+			l.endPart()
+			l.parts = append(l.parts, wordPart{text: string(runes[i]), quote: '\\'})
+		case op != "":
+			start := i
+			if fd := l.takeFdPrefix(op); fd != "" {
+				op = fd + op
+				start = l.start
+			}
+			l.endWord(i)
+			l.tokens = append(l.tokens, token{op: op, start: start, end: i + n})
+			i += n - 1
+		default:
 			// 일반 문자 추가
-			currentToken.WriteRune(char)
+			l.beginWord(i)
+			l.current.WriteRune(char)
 		}
 	}
 
 	// 마지막 토큰 추가
-	args = addTokenIfNotEmpty(args, currentToken.String())
-	return args
+	l.endWord(len(runes))
+	return l.tokens
+}
+
+type lexer struct {
+	tokens  []token
+	parts   word
+	current strings.Builder
+	quote   rune
+	inWord  bool
+	start   int
+}
+
+// beginWord 새 단어가 시작되는 위치를 기록
+func (l *lexer) beginWord(pos int) {
+	if !l.inWord {
+		l.inWord = true
+		l.start = pos
+	}
+}
+
+// endPart 현재까지 모은 문자를 단어 조각으로 추가
+func (l *lexer) endPart() {
+	if l.current.Len() > 0 || l.quote != 0 {
+		l.parts = append(l.parts, wordPart{text: l.current.String(), quote: l.quote})
+	}
+	l.current.Reset()
+	l.quote = 0
+}
+
+// endWord 현재 단어가 비어있지 않거나 인용부호로 감싼 빈 값이면 토큰으로 추가
+// 예: echo "" a -> [echo, "", a]
+func (l *lexer) endWord(pos int) {
+	l.endPart()
+	if l.inWord && (l.parts.String() != "" || l.parts.quoted()) {
+		l.tokens = append(l.tokens, token{word: l.parts, start: l.start, end: pos})
+	}
+	l.parts = nil
+	l.inWord = false
+}
+
+// takeFdPrefix 리다이렉션 연산자 바로 앞의 "1", "2" 같은 파일 디스크립터 번호를 가져옵니다
+// 예: "2>&1"에서 ">&1" 앞의 "2"
+func (l *lexer) takeFdPrefix(op string) string {
+	if !strings.HasPrefix(op, ">") && !strings.HasPrefix(op, "<") {
+		return ""
+	}
+	if len(l.parts) != 0 || l.current.Len() != 1 {
+		return ""
+	}
+	fd := l.current.String()
+	if fd != "0" && fd != "1" && fd != "2" {
+		return ""
+	}
+	l.current.Reset()
+	l.inWord = false
+	return fd
 }
 
 // isQuoteChar 인용부호 문자인지 확인
@@ -38,11 +171,153 @@ func isQuoteChar(r rune) bool {
 	return r == '"' || r == '\''
 }
 
-// addTokenIfNotEmpty 토큰이 비어있지 않으면 배열에 추가
-// 예: addTokenIfNotEmpty([]string{"ls"}, "file") -> []string{"ls", "file"}
-func addTokenIfNotEmpty(args []string, token string) []string {
-	if token != "" {
-		return append(args, token)
+// operatorEscapable 확장 설정과 관계없이 백슬래시로 이스케이프할 수 있는 문자 (공백, 인용부호, 연산자)
+const operatorEscapable = ` '"<>&|;`
+
+// escapableChars 백슬래시로 이스케이프할 수 있는 문자
+// 공백, 인용부호, 연산자는 항상 이스케이프할 수 있고, $, ~, glob 문자는 해당 확장을 사용할 때만 이스케이프합니다
+// Windows에서는 백슬래시가 경로 구분자이므로 이스케이프를 인식하지 않습니다 (인용부호를 사용)
+// 예: 확장을 사용하지 않으면 C:\temp\*.txt -> C:\temp\*.txt, 공백 이스케이프 a\ b -> "a b"
+func (c config) escapableChars() string {
+	if runtime.GOOS == "windows" {
+		return ""
 	}
-	return args
+	chars := operatorEscapable
+	if c.ExpandEnv {
+		chars += "$"
+	}
+	if c.ExpandTilde {
+		chars += "~"
+	}
+	if c.Glob != GlobOff {
+		chars += globMetaChars
+	}
+	return chars
+}
+
+// isOperatorStart 연산자의 첫 문자인지 확인
+func isOperatorStart(r rune) bool {
+	return strings.ContainsRune("<>&|;", r)
+}
+
+// readOperator runes의 앞부분에서 연산자를 읽고 연산자와 길이를 반환
+// 연산자가 아니면 빈 문자열을 반환합니다 (예: 단독으로 쓰인 &, |)
+// 예: readOperator([]rune(">> out")) -> ">>", 2
+func readOperator(runes []rune) (string, int) {
+	next := func(i int) rune {
+		if i < len(runes) {
+			return runes[i]
+		}
+		return 0
+	}
+
+	switch runes[0] {
+	case '>':
+		if next(1) == '>' {
+			return ">>", 2
+		}
+		if next(1) == '&' && (next(2) == '1' || next(2) == '2') {
+			return ">&" + string(next(2)), 3
+		}
+		return ">", 1
+	case '&', '|':
+		if next(1) == runes[0] {
+			return string(runes[:2]), 2
+		}
+		return "", 0
+	default:
+		return string(runes[0]), 1
+	}
+}
+
+// isListOperator 명령어를 순서대로 연결하는 연산자인지 확인
+func isListOperator(op string) bool {
+	return op == "&&" || op == "||" || op == ";"
+}
+
+// listItem &&, ||, ;로 연결된 명령어 목록의 항목
+type listItem struct {
+	op      string // 이전 명령어와의 연결 연산자 (첫 명령어는 빈 문자열)
+	command simpleCommand
+	source  string // 원본 문자열에서 해당 명령어 부분
+}
+
+// parseCommandList 명령어 문자열을 &&, ||, ;로 연결된 명령어 목록으로 파싱합니다
+// 예: make build && make test -> [{"" make build} {&& make test}]
+func parseCommandList(cmd string, escapable string) ([]listItem, error) {
+	runes := []rune(cmd)
+	tokens := lexCommand(cmd, true, escapable)
+
+	var items []listItem
+	op := ""
+	begin := 0
+	for i := 0; i <= len(tokens); i++ {
+		if i < len(tokens) && !isListOperator(tokens[i].op) {
+			continue
+		}
+
+		group := tokens[begin:i]
+		if len(group) == 0 {
+			// 마지막의 ;는 허용 (예: "echo a;")
+			if i == len(tokens) && op == ";" {
+				break
+			}
+			if i < len(tokens) || op != "" {
+				return nil, fmt.Errorf("연산자 앞뒤에 명령어가 없습니다: %s", cmd)
+			}
+			break
+		}
+
+		sc, err := parseSimpleCommand(group)
+		if err != nil {
+			return nil, err
+		}
+		source := strings.TrimSpace(string(runes[group[0].start:group[len(group)-1].end]))
+		items = append(items, listItem{op: op, command: sc, source: source})
+
+		if i < len(tokens) {
+			op = tokens[i].op
+		}
+		begin = i + 1
+	}
+	return items, nil
+}
+
+// simpleCommand 환경변수 지정, 인수, 리다이렉션으로 나눈 하나의 명령어
+type simpleCommand struct {
+	assignments []word
+	words       []word
+	redirects   []redirect
+}
+
+// parseSimpleCommand 토큰을 환경변수 지정, 인수, 리다이렉션으로 나눕니다
+// 예: GOOS=linux sort < in.txt -> assignments: [GOOS=linux], words: [sort], redirects: [< in.txt]
+func parseSimpleCommand(tokens []token) (simpleCommand, error) {
+	var sc simpleCommand
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if t.op == "" {
+			sc.words = append(sc.words, t.word)
+			continue
+		}
+
+		r, err := parseRedirect(t.op)
+		if err != nil {
+			return sc, err
+		}
+		if r.op != ">&" {
+			if i+1 >= len(tokens) || tokens[i+1].op != "" {
+				return sc, fmt.Errorf("리다이렉션 대상 파일이 없습니다: %s", t.op)
+			}
+			i++
+			r.target = tokens[i].word
+		}
+		sc.redirects = append(sc.redirects, r)
+	}
+
+	for len(sc.words) > 0 && isAssignment(sc.words[0]) {
+		sc.assignments = append(sc.assignments, sc.words[0])
+		sc.words = sc.words[1:]
+	}
+	return sc, nil
 }
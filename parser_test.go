@@ -1,7 +1,9 @@
 package easycmd
 
 import (
+	"fmt"
 	"reflect"
+	"runtime"
 	"testing"
 )
 
@@ -54,7 +56,7 @@ func TestParseCommandArgs(t *testing.T) {
 		{
 			name:     "빈 단일 인용부호",
 			input:    "echo ''",
-			expected: []string{"echo"},
+			expected: []string{"echo", ""},
 		},
 		{
 			name:     "단일 인용부호 안의 공백",
@@ -71,7 +73,7 @@ func TestParseCommandArgs(t *testing.T) {
 		{
 			name:     "빈 이중 인용부호",
 			input:    `echo ""`,
-			expected: []string{"echo"},
+			expected: []string{"echo", ""},
 		},
 		{
 			name:     "이중 인용부호 안의 공백",
@@ -95,12 +97,12 @@ func TestParseCommandArgs(t *testing.T) {
 		{
 			name:     "인용부호만",
 			input:    `''`,
-			expected: []string{},
+			expected: []string{""},
 		},
 		{
 			name:     "이중 인용부호만",
 			input:    `""`,
-			expected: []string{},
+			expected: []string{""},
 		},
 		{
 			name:     "닫히지 않은 단일 인용부호",
@@ -156,3 +158,234 @@ func TestParseCommandArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSimpleCommand(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		args      []string
+		redirects []string
+		wantErr   bool
+	}{
+		{
+			name:  "리다이렉션 없음",
+			input: "sort -r file.txt",
+			args:  []string{"sort", "-r", "file.txt"},
+		},
+		{
+			name:      "입력과 출력 리다이렉션",
+			input:     "sort < in.txt > out.txt",
+			args:      []string{"sort"},
+			redirects: []string{"0< in.txt", "1> out.txt"},
+		},
+		{
+			name:      "공백 없는 리다이렉션",
+			input:     "sort <in.txt >>out.txt",
+			args:      []string{"sort"},
+			redirects: []string{"0< in.txt", "1>> out.txt"},
+		},
+		{
+			name:      "stderr 리다이렉션과 복제",
+			input:     "make build > build.log 2>&1",
+			args:      []string{"make", "build"},
+			redirects: []string{"1> build.log", "2>& 1"},
+		},
+		{
+			name:      "stderr 파일 리다이렉션",
+			input:     "make 2>err.log",
+			args:      []string{"make"},
+			redirects: []string{"2> err.log"},
+		},
+		{
+			name:  "인용부호 안의 연산자",
+			input: `echo "a > b" '<c>'`,
+			args:  []string{"echo", "a > b", "<c>"},
+		},
+		{
+			name:  "이스케이프된 연산자",
+			input: `echo \> a\<b`,
+			args:  []string{"echo", ">", "a<b"},
+		},
+		{
+			name:  "숫자로 끝나는 인수는 파일 디스크립터가 아님",
+			input: "echo a2>out.txt",
+			args:  []string{"echo", "a2"},
+			redirects: []string{
+				"1> out.txt",
+			},
+		},
+		{
+			name:  "Windows 경로의 백슬래시 유지",
+			input: `dir C:\temp`,
+			args:  []string{"dir", `C:\temp`},
+		},
+		{
+			name:  "앞에 지정된 환경변수는 인수가 아님",
+			input: "GOOS=linux CGO_ENABLED=0 go build -ldflags=-s",
+			args:  []string{"go", "build", "-ldflags=-s"},
+		},
+		{
+			name:    "리다이렉션 대상 없음",
+			input:   "sort >",
+			wantErr: true,
+		},
+		{
+			name:    "잘못된 파일 디스크립터",
+			input:   "sort 2< in.txt",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, err := parseSimpleCommand(lexCommand(tt.input, true, operatorEscapable))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseSimpleCommand(%q) 에러 기대, 결과: nil", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSimpleCommand(%q) 에러: %v", tt.input, err)
+			}
+
+			var args []string
+			for _, w := range sc.words {
+				args = append(args, w.String())
+			}
+			var redirects []string
+			for _, r := range sc.redirects {
+				redirects = append(redirects, fmt.Sprintf("%d%s %s", r.fd, r.op, r.target))
+			}
+
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("args = %q, 기대값: %q", args, tt.args)
+			}
+			if !reflect.DeepEqual(redirects, tt.redirects) {
+				t.Errorf("redirects = %q, 기대값: %q", redirects, tt.redirects)
+			}
+		})
+	}
+}
+
+func TestParseCommandList(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string // "연산자|원본" 형식
+		wantErr  bool
+	}{
+		{
+			name:     "단일 명령어",
+			input:    "make build",
+			expected: []string{"|make build"},
+		},
+		{
+			name:     "&& 연결",
+			input:    "make build && make test",
+			expected: []string{"|make build", "&&|make test"},
+		},
+		{
+			name:     "공백 없는 연산자",
+			input:    "a&&b||c;d",
+			expected: []string{"|a", "&&|b", "|||c", ";|d"},
+		},
+		{
+			name:     "인용부호 유지한 원본",
+			input:    `echo 'a && b' ; echo "c"`,
+			expected: []string{`|echo 'a && b'`, `;|echo "c"`},
+		},
+		{
+			name:     "리다이렉션과 함께 사용",
+			input:    "sort < in.txt > out.txt && cat out.txt",
+			expected: []string{"|sort < in.txt > out.txt", "&&|cat out.txt"},
+		},
+		{
+			name:     "마지막 세미콜론",
+			input:    "echo a;",
+			expected: []string{"|echo a"},
+		},
+		{
+			name:     "이스케이프된 세미콜론",
+			input:    `find . -exec rm {} \;`,
+			expected: []string{`|find . -exec rm {} \;`},
+		},
+		{
+			name:     "단독 파이프와 앰퍼샌드는 일반 문자",
+			input:    "echo a | b & c",
+			expected: []string{"|echo a | b & c"},
+		},
+		{
+			name:     "빈 문자열",
+			input:    "",
+			expected: nil,
+		},
+		{
+			name:    "앞에 연산자",
+			input:   "&& make",
+			wantErr: true,
+		},
+		{
+			name:    "뒤에 연산자",
+			input:   "make &&",
+			wantErr: true,
+		},
+		{
+			name:    "연속된 연산자",
+			input:   "make && || make test",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := parseCommandList(tt.input, operatorEscapable)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseCommandList(%q) 에러 기대, 결과: nil", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCommandList(%q) 에러: %v", tt.input, err)
+			}
+
+			var result []string
+			for _, item := range list {
+				result = append(result, item.op+"|"+item.source)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("parseCommandList(%q) = %q, 기대값: %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEscapableChars(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   config
+		input    string
+		expected []string
+		unixOnly bool
+	}{
+		{name: "확장을 사용하지 않으면 Windows 경로 유지", input: `echo C:\temp\*.txt C:\$Recycle.Bin`, expected: []string{"echo", `C:\temp\*.txt`, `C:\$Recycle.Bin`}},
+		{name: "변수 확장을 사용하면 $ 이스케이프", config: config{ExpandEnv: true}, input: `echo \$HOME`, expected: []string{"echo", "$HOME"}, unixOnly: true},
+		{name: "glob을 사용하면 glob 문자 이스케이프", config: config{Glob: GlobKeep}, input: `echo \*.txt`, expected: []string{"echo", "*.txt"}, unixOnly: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.unixOnly && runtime.GOOS == "windows" {
+				t.Skip("Windows에서는 백슬래시 이스케이프를 인식하지 않습니다")
+			}
+			var result []string
+			for _, tok := range lexCommand(tt.input, true, tt.config.escapableChars()) {
+				result = append(result, tok.word.String())
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("lexCommand(%q) = %q, 기대값: %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package easycmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// PipelineResult 파이프라인 각 단계의 종료 코드
+// 시그널로 종료되었거나 시작되지 못한 단계의 종료 코드는 -1입니다
+type PipelineResult struct {
+	ExitCodes []int
+}
+
+// PipelineError pipefail과 같이 실패한 단계 중 가장 마지막 단계를 나타내는 에러
+type PipelineError struct {
+	Stage     int // 실패한 단계의 인덱스 (0부터 시작)
+	Command   string
+	ExitCodes []int
+	Err       error
+}
+
+func (e *PipelineError) Error() string {
+	return fmt.Sprintf("파이프라인 %d번째 명령어가 실패했습니다 (%s, 종료 코드: %v): %v", e.Stage+1, e.Command, e.ExitCodes, e.Err)
+}
+
+func (e *PipelineError) Unwrap() error {
+	return e.Err
+}
+
+func (c *Cmd) RunPipeline(commandStrs ...string) (PipelineResult, error) {
+	commands := make([]command, len(commandStrs))
+	for i, commandStr := range commandStrs {
+		commands[i] = command(commandStr)
+	}
+	return runPipeline(context.Background(), commands, c.c)
+}
+
+// runPipeline 각 단계의 stdout을 다음 단계의 stdin으로 연결하여 모든 단계를 동시에 실행합니다
+// 첫 단계는 설정된 stdin을, 마지막 단계는 설정된 stdout을 사용하며 stderr은 모든 단계가 공유합니다
+func runPipeline(parent context.Context, commands []command, config config) (PipelineResult, error) {
+	result := PipelineResult{ExitCodes: make([]int, len(commands))}
+	for i := range result.ExitCodes {
+		result.ExitCodes[i] = -1
+	}
+	if config.err != nil {
+		return result, config.err
+	}
+	if len(commands) == 0 {
+		return result, EmptyCmdError
+	}
+	for _, command := range commands {
+		if command == "" {
+			return result, EmptyCmdError
+		}
+	}
+
+	stages := make([]process, len(commands))
+	for i, command := range commands {
+		stage, err := pipelineStage(command, config)
+		if err != nil {
+			return result, err
+		}
+		stages[i] = stage
+	}
+
+	config.Logger.ParsedCommand(joinPipeline(commands))
+	for _, stage := range stages {
+		config.Logger.ExecutionCommand(stage.name, stage.args)
+	}
+	config.Logger.ExecutionDirectory(string(config.RunDir))
+	config.Logger.ExecutionStart()
+
+	for i := range stages {
+		if err := stages[i].checkRedirects(config.Credential); err != nil {
+			config.Logger.StartFailed(err)
+			return result, err
+		}
+		if config.Policy != nil {
+			checked, violation := config.Policy.check(stages[i], config)
+			if violation != nil {
+				config.Logger.PolicyViolated(violation)
+				return result, violation
+			}
+			stages[i] = checked
+		}
+		if !config.Preflight {
+			continue
+		}
+		checked, err := stages[i].preflight(config)
+		if err != nil {
+			config.Logger.StartFailed(err)
+			return result, err
+		}
+		stages[i] = checked
+	}
+
+	if config.DryRun != nil {
+		lines := make([]string, len(stages))
+		for i, stage := range stages {
+			lines[i] = stage.shellString(config.Env)
+			result.ExitCodes[i] = 0
+		}
+		dryRun(strings.Join(lines, " | "), config)
+		config.Logger.ExecutionCompleted()
+		return result, nil
+	}
+
+	e := newExecution(parent, config)
+	defer e.close()
+
+	// stderr은 모든 단계가 함께 쓰므로 쓰기를 직렬화하고, 마지막 단계의 stdout과 같은 Writer라면 하나로 공유합니다
+	stdOut, stdErr, flushOutput := config.outputWriters()
+	if sameWriter(stdOut, stdErr) {
+		shared := newSyncWriter(stdOut)
+		stdOut, stdErr = shared, shared
+	} else {
+		stdErr = newSyncWriter(stdErr)
+	}
+
+	// 각 단계가 사용하는 파이프 (부모 프로세스 쪽 파일)
+	stagePipes := make([][]*os.File, len(commands))
+	closePipes := func() {
+		for _, files := range stagePipes {
+			for _, f := range files {
+				f.Close()
+			}
+		}
+	}
+
+	reqs := make([]Request, len(commands))
+	var stdin io.Reader = config.StdIn
+	for i, stage := range stages {
+		s := streams{stdIn: stdin, stdOut: stdOut, stdErr: stdErr}
+		last := i == len(commands)-1
+		if !last {
+			r, w, err := os.Pipe()
+			if err != nil {
+				closePipes()
+				return result, fmt.Errorf("파이프를 생성할 수 없습니다: %w", err)
+			}
+			stagePipes[i] = append(stagePipes[i], w)
+			stagePipes[i+1] = append(stagePipes[i+1], r)
+			s.stdOut = w
+			stdin = r
+		}
+		closeFiles, err := s.redirect(stage.redirects, string(config.RunDir))
+		if err != nil {
+			closePipes()
+			return result, e.startError(err)
+		}
+		defer closeFiles()
+
+		req := e.request(stage.name, stage.args)
+		if len(stage.env) > 0 {
+			req.Env = mergeEnv(config.environ(), stage.env)
+			config.Logger.EnvironmentOverride(stage.env)
+		}
+		req.Stdin = s.stdIn
+		// 유휴 타임아웃은 중간 단계가 다음 단계로 보내는 출력도 감시합니다
+		req.Stdout, req.Stderr = e.wrapOutputs(s.stdOut, s.stdErr)
+		reqs[i] = req
+	}
+
+	cancel := e.withCancel()
+	procs := make([]Process, len(reqs))
+	for i, req := range reqs {
+		proc, err := e.start(req)
+		if err != nil {
+			closePipes()
+			cancel()
+			for _, started := range procs[:i] {
+				started.Wait()
+			}
+			return result, e.startError(err)
+		}
+		procs[i] = proc
+	}
+
+	errs := make([]error, len(procs))
+	var wg sync.WaitGroup
+	for i, proc := range procs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = proc.Wait()
+			result.ExitCodes[i] = exitCode(errs[i])
+			// 끝난 단계의 파이프를 닫아야 다음 단계가 EOF를, 앞 단계가 SIGPIPE를 받습니다
+			for _, f := range stagePipes[i] {
+				f.Close()
+			}
+		}()
+	}
+	wg.Wait()
+	flushOutput()
+
+	for i := len(errs) - 1; i >= 0; i-- {
+		if errs[i] == nil {
+			continue
+		}
+		if interrupted := e.interruptError(errs[i]); interrupted != nil {
+			return result, interrupted
+		}
+		if limitErr := e.limitError(errs[i]); limitErr != nil {
+			errs[i] = limitErr
+		} else {
+			config.Logger.ExecutionFailed(errs[i], false)
+		}
+		return result, &PipelineError{
+			Stage:     i,
+			Command:   commands[i].String(),
+			ExitCodes: result.ExitCodes,
+			Err:       errs[i],
+		}
+	}
+
+	config.Logger.ExecutionCompleted()
+
+	return result, nil
+}
+
+// pipelineStage 파이프라인의 한 단계를 Run과 같은 방식으로 해석합니다
+// 명령어 앞의 환경변수 지정, 리다이렉션, 확장을 적용하며 &&, ||, ;로 연결된 명령어는 사용할 수 없습니다
+func pipelineStage(command command, config config) (process, error) {
+	if command.isShell() {
+		return config.shell().process(command.script(), nil), nil
+	}
+	if command.isWrapped() || config.LiteralArgs {
+		p := process{display: command.String(), name: command.Name(), args: command.Args()}
+		if command.isPowershell() {
+			p.mode = modePowershell
+		}
+		return p, nil
+	}
+
+	list, err := parseCommandList(command.String(), config.escapableChars())
+	if err != nil {
+		return process{}, err
+	}
+	if len(list) == 0 {
+		return process{}, EmptyCmdError
+	}
+	if len(list) > 1 {
+		return process{}, fmt.Errorf("파이프라인 단계에는 &&, ||, ;를 사용할 수 없습니다: %s", command)
+	}
+	return list[0].command.process(command.String(), config)
+}
+
+func joinPipeline(commands []command) string {
+	strs := make([]string, len(commands))
+	for i, command := range commands {
+		strs[i] = command.String()
+	}
+	return strings.Join(strs, " | ")
+}
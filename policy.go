@@ -0,0 +1,222 @@
+package easycmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Policy 실행할 수 있는 명령어를 제한하는 정책
+// 신뢰할 수 없는 설정에서 온 명령어를 실행할 때 사용하며, 프로세스를 시작하기 전에 검사합니다
+type Policy struct {
+	// AllowCommands 실행을 허용할 실행 파일 (비어있으면 모두 허용)
+	// 이름(예: git)은 PATH에서 찾은 실행 파일의 이름과, 절대 경로(예: /usr/bin/git)는 실행 파일의 경로와 비교합니다
+	// 이름 항목은 경로를 지정한 명령어(예: ./git, /tmp/git)를 허용하지 않습니다
+	AllowCommands []string
+	// DenyCommands 실행을 거부할 실행 파일 (AllowCommands보다 우선)
+	// 이름 항목은 경로를 지정한 명령어(예: /bin/rm)의 이름과도 비교합니다
+	DenyCommands []string
+	// DenyArgs 인수 중 하나라도 일치하면 거부할 정규식
+	DenyArgs []*regexp.Regexp
+	// DenyShell RunShell, RunShellArgs, RunScript 등 쉘 스크립트 실행 거부
+	DenyShell bool
+	// DenyPowershell RunPowershell 계열 실행 거부
+	DenyPowershell bool
+	// AllowDirs 실행을 허용할 디렉토리 (하위 디렉토리 포함, 비어있으면 모두 허용)
+	// 리다이렉션으로 읽거나 쓰는 파일도 이 디렉토리 안에 있어야 합니다
+	AllowDirs []string
+	// DenyEnv 명령어 앞에 지정할 수 없는 환경변수 이름 (예: LD_PRELOAD)
+	DenyEnv []string
+}
+
+// PolicyViolation 정책에 의해 실행이 거부되었음을 나타내는 에러
+type PolicyViolation struct {
+	Command string // 거부된 명령어
+	Rule    string // 위반한 정책 항목 (예: DenyCommands)
+	Reason  string
+}
+
+func (v *PolicyViolation) Error() string {
+	return fmt.Sprintf("정책에 의해 실행이 거부되었습니다 (%s): %s: %s", v.Rule, v.Reason, v.Command)
+}
+
+// check 정책에 따라 p를 실행할 수 있는지 검사합니다
+// 검사한 실행 파일을 그대로 실행하도록, 실행 파일을 찾은 경우 p.name을 그 경로로 바꾼 process를 반환합니다
+func (policy *Policy) check(p process, config config) (process, *PolicyViolation) {
+	violation := func(rule string, format string, args ...any) *PolicyViolation {
+		return &PolicyViolation{Command: p.display, Rule: rule, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	switch {
+	case p.mode == modeShell && policy.DenyShell:
+		return p, violation("DenyShell", "쉘 스크립트 실행이 허용되지 않습니다")
+	case p.mode == modePowershell && policy.DenyPowershell:
+		return p, violation("DenyPowershell", "PowerShell 실행이 허용되지 않습니다")
+	}
+
+	for _, kv := range p.env {
+		name, _, _ := strings.Cut(kv, "=")
+		if slices.Contains(policy.DenyEnv, name) {
+			return p, violation("DenyEnv", "환경변수 %s를 지정할 수 없습니다", name)
+		}
+	}
+
+	dir := string(config.RunDir)
+	if len(policy.AllowDirs) > 0 && !isAllowedDir(dir, policy.AllowDirs) {
+		return p, violation("AllowDirs", "허용되지 않은 실행 디렉토리입니다: %s", dir)
+	}
+	if len(policy.AllowDirs) > 0 {
+		for _, r := range p.redirects {
+			if r.op == ">&" {
+				continue
+			}
+			target := r.target.String()
+			if !filepath.IsAbs(target) {
+				target = filepath.Join(dir, target)
+			}
+			if !isAllowedPath(target, policy.AllowDirs) {
+				return p, violation("AllowDirs", "허용되지 않은 디렉토리의 파일로 리다이렉션할 수 없습니다: %s", r.target)
+			}
+		}
+	}
+
+	env := config.environ()
+	if len(p.env) > 0 {
+		env = mergeEnv(env, p.env)
+	}
+	paths := executablePaths(p.name, dir, env)
+	if denyCommand(p.name, paths, policy.DenyCommands) {
+		return p, violation("DenyCommands", "실행이 거부된 명령어입니다: %s", p.name)
+	}
+	if len(policy.AllowCommands) > 0 && !matchCommand(p.name, paths, policy.AllowCommands) {
+		return p, violation("AllowCommands", "허용되지 않은 명령어입니다: %s", p.name)
+	}
+
+	for _, arg := range p.args {
+		for _, re := range policy.DenyArgs {
+			if re.MatchString(arg) {
+				return p, violation("DenyArgs", "인수 %q가 %s와 일치합니다", arg, re)
+			}
+		}
+	}
+
+	// 실행 시 부모 프로세스의 PATH로 다시 찾으면 명령어 앞의 PATH=로 검사한 것과 다른 파일이 실행될 수 있습니다
+	if len(paths) > 0 {
+		p.name = paths[0]
+	}
+	return p, nil
+}
+
+// executablePaths PATH에서 찾은 실행 파일의 절대 경로와, 심볼릭 링크인 경우 실제 경로
+func executablePaths(name string, dir string, env []string) []string {
+	path, err := lookPath(name, dir, env)
+	if err != nil {
+		return nil
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+	}
+	paths := []string{path}
+	if real, err := filepath.EvalSymlinks(path); err == nil && real != path {
+		paths = append(paths, real)
+	}
+	return paths
+}
+
+// matchCommand 실행 파일이 entries 중 하나와 일치하는지 확인합니다
+// 절대 경로 항목은 실행 파일의 경로와 비교하고, 이름 항목은 PATH에서 찾은 명령어(경로 구분자가 없는 이름)만 실행 파일의 이름과 비교합니다
+// 예: 항목 git은 git과 일치하지만 ./git, /tmp/git과는 일치하지 않습니다
+func matchCommand(name string, paths []string, entries []string) bool {
+	for _, entry := range entries {
+		if filepath.IsAbs(entry) {
+			if slices.Contains(paths, filepath.Clean(entry)) {
+				return true
+			}
+			continue
+		}
+		if hasPathSeparator(name) {
+			continue
+		}
+		if entry == name {
+			return true
+		}
+		for _, path := range paths {
+			if entry == filepath.Base(path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// denyCommand 실행 파일이 거부 목록 entries 중 하나와 일치하는지 확인합니다
+// 경로를 지정하여 거부 목록을 우회할 수 없도록 이름 항목은 경로를 지정한 명령어의 이름과도 비교합니다
+// 예: 항목 rm은 rm, /bin/rm, ./rm과 모두 일치합니다
+func denyCommand(name string, paths []string, entries []string) bool {
+	if matchCommand(name, paths, entries) {
+		return true
+	}
+	return hasPathSeparator(name) && slices.Contains(entries, filepath.Base(name))
+}
+
+// hasPathSeparator 명령어 이름에 경로 구분자가 있는지 확인합니다 (PATH에서 찾지 않는 명령어)
+func hasPathSeparator(name string) bool {
+	return strings.ContainsRune(name, '/') || strings.ContainsRune(name, filepath.Separator)
+}
+
+// isAllowedPath 파일이 allowed 중 하나의 디렉토리 안에 있는지 확인합니다
+// 심볼릭 링크로 다른 디렉토리의 파일을 가리키는 경우도 거부하도록 실제 경로도 확인합니다
+func isAllowedPath(path string, allowed []string) bool {
+	if !isAllowedDir(path, allowed) {
+		return false
+	}
+	real := realPath(path)
+	realAllowed := make([]string, len(allowed))
+	for i, a := range allowed {
+		realAllowed[i] = realPath(a)
+	}
+	return isAllowedDir(real, realAllowed)
+}
+
+// realPath 심볼릭 링크를 따라간 실제 경로 (파일이 아직 없으면 상위 디렉토리의 실제 경로 기준)
+func realPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	if real, err := filepath.EvalSymlinks(abs); err == nil {
+		return real
+	}
+	if parent, err := filepath.EvalSymlinks(filepath.Dir(abs)); err == nil {
+		return filepath.Join(parent, filepath.Base(abs))
+	}
+	return abs
+}
+
+// isAllowedDir 실행 디렉토리가 allowed 중 하나이거나 그 하위 디렉토리인지 확인합니다
+func isAllowedDir(dir string, allowed []string) bool {
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	for _, a := range allowed {
+		allowedAbs, err := filepath.Abs(a)
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(allowedAbs, abs)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
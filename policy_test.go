@@ -0,0 +1,58 @@
+package easycmd
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestPolicyCheck(t *testing.T) {
+	dir := t.TempDir()
+	outside := t.TempDir()
+	if err := os.Symlink(outside, filepath.Join(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+	output := func(target string) []redirect {
+		return []redirect{{fd: 1, op: ">", target: word{{text: target}}}}
+	}
+	tests := []struct {
+		name     string
+		policy   Policy
+		process  process
+		config   config
+		expected string // 위반한 정책 항목 (빈 문자열이면 허용)
+	}{
+		{name: "정책 없음", process: process{name: "rm", args: []string{"-rf", "/"}}},
+		{name: "허용된 명령어", policy: Policy{AllowCommands: []string{"git", "go"}}, process: process{name: "git"}},
+		{name: "허용되지 않은 명령어", policy: Policy{AllowCommands: []string{"git"}}, process: process{name: "curl"}, expected: "AllowCommands"},
+		{name: "경로로 우회한 거부 명령어", policy: Policy{DenyCommands: []string{"rm"}}, process: process{name: "/bin/rm"}, expected: "DenyCommands"},
+		{name: "경로를 지정한 명령어는 이름 항목으로 허용되지 않음", policy: Policy{AllowCommands: []string{"git"}}, process: process{name: "/tmp/evil/git"}, expected: "AllowCommands"},
+		{name: "상대 경로 명령어는 이름 항목으로 허용되지 않음", policy: Policy{AllowCommands: []string{"ls"}}, process: process{name: "./ls"}, expected: "AllowCommands"},
+		{name: "거부 인수", policy: Policy{DenyArgs: []*regexp.Regexp{regexp.MustCompile(`^--force`)}}, process: process{name: "git", args: []string{"push", "--force-with-lease"}}, expected: "DenyArgs"},
+		{name: "쉘 실행 거부", policy: Policy{DenyShell: true}, process: process{name: "bash", mode: modeShell}, expected: "DenyShell"},
+		{name: "쉘이 아닌 bash 실행", policy: Policy{DenyShell: true}, process: process{name: "bash"}},
+		{name: "PowerShell 실행 거부", policy: Policy{DenyPowershell: true}, process: process{name: "powershell.exe", mode: modePowershell}, expected: "DenyPowershell"},
+		{name: "거부 환경변수", policy: Policy{DenyEnv: []string{"LD_PRELOAD"}}, process: process{name: "ls", env: []string{"LD_PRELOAD=evil.so"}}, expected: "DenyEnv"},
+		{name: "허용 디렉토리의 하위 디렉토리", policy: Policy{AllowDirs: []string{dir}}, process: process{name: "ls"}, config: config{RunDir: runDir(dir + "/sub")}},
+		{name: "허용되지 않은 디렉토리", policy: Policy{AllowDirs: []string{dir}}, process: process{name: "ls"}, config: config{RunDir: runDir(dir + "/../other")}, expected: "AllowDirs"},
+		{name: "허용 디렉토리 안의 리다이렉션", policy: Policy{AllowDirs: []string{dir}}, process: process{name: "ls", redirects: output("out.txt")}, config: config{RunDir: runDir(dir)}},
+		{name: "허용 디렉토리 밖의 리다이렉션", policy: Policy{AllowDirs: []string{dir}}, process: process{name: "ls", redirects: output("/etc/passwd")}, config: config{RunDir: runDir(dir)}, expected: "AllowDirs"},
+		{name: "상대 경로로 벗어난 리다이렉션", policy: Policy{AllowDirs: []string{dir}}, process: process{name: "ls", redirects: output("../out.txt")}, config: config{RunDir: runDir(dir)}, expected: "AllowDirs"},
+		{name: "심볼릭 링크로 벗어난 리다이렉션", policy: Policy{AllowDirs: []string{dir}}, process: process{name: "ls", redirects: output("link/out.txt")}, config: config{RunDir: runDir(dir)}, expected: "AllowDirs"},
+		{name: "파일 디스크립터 복제", policy: Policy{AllowDirs: []string{dir}}, process: process{name: "ls", redirects: []redirect{{fd: 2, op: ">&", target: word{{text: "1"}}}}}, config: config{RunDir: runDir(dir)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, violation := tt.policy.check(tt.process, tt.config)
+			result := ""
+			if violation != nil {
+				result = violation.Rule
+			}
+			if result != tt.expected {
+				t.Errorf("check() = %v, 기대값: %q", violation, tt.expected)
+			}
+		})
+	}
+}
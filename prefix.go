@@ -0,0 +1,51 @@
+package easycmd
+
+import (
+	"io"
+	"os"
+	"sync"
+)
+
+// Color 출력 접두사에 사용하는 ANSI 색상
+type Color string
+
+const (
+	ColorNone    Color = ""
+	ColorRed     Color = "31"
+	ColorGreen   Color = "32"
+	ColorYellow  Color = "33"
+	ColorBlue    Color = "34"
+	ColorMagenta Color = "35"
+	ColorCyan    Color = "36"
+)
+
+// prefixWriteMu 여러 Cmd가 같은 Writer에 동시에 출력해도 줄이 섞이지 않도록 보호합니다
+var prefixWriteMu sync.Mutex
+
+// newPrefixWriter 각 줄 앞에 접두사를 붙여 out에 출력하는 lineWriter를 만듭니다
+// out이 터미널이 아니거나 NO_COLOR 환경변수가 설정된 경우 색상을 사용하지 않습니다
+func newPrefixWriter(out io.Writer, label string, color Color, maxLength int) *lineWriter {
+	prefix := label + " | "
+	if color != ColorNone && isColorTerminal(out) {
+		prefix = "\x1b[" + string(color) + "m" + label + " |\x1b[0m "
+	}
+
+	return newLineWriter(func(line string) {
+		prefixWriteMu.Lock()
+		defer prefixWriteMu.Unlock()
+		io.WriteString(out, prefix+line+"\n")
+	}, maxLength)
+}
+
+// isColorTerminal out이 색상을 출력할 수 있는 터미널인지 확인
+func isColorTerminal(out io.Writer) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	f, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,39 @@
+package easycmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	pw := newPrefixWriter(out, "api", ColorRed, 0)
+
+	// when
+	pw.Write([]byte("hello\nwor"))
+	pw.Write([]byte("ld"))
+	pw.Flush()
+
+	// then - 터미널이 아니므로 색상 코드 없이 출력
+	expected := "api | hello\napi | world\n"
+	if out.String() != expected {
+		t.Errorf("output = %q, 기대값: %q", out.String(), expected)
+	}
+}
+
+func TestIsColorTerminal(t *testing.T) {
+	if isColorTerminal(&bytes.Buffer{}) {
+		t.Error("isColorTerminal(bytes.Buffer) = true, 기대값: false")
+	}
+
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if isColorTerminal(f) {
+		t.Error("isColorTerminal(일반 파일) = true, 기대값: false")
+	}
+}
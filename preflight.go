@@ -0,0 +1,257 @@
+package easycmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// ExecutableNotFoundError 실행 파일을 찾을 수 없음을 나타내는 에러
+type ExecutableNotFoundError struct {
+	Name        string
+	Suggestions []string // PATH에서 찾은 비슷한 이름의 실행 파일
+}
+
+func (e *ExecutableNotFoundError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("실행 파일을 찾을 수 없습니다: %s", e.Name)
+	}
+	return fmt.Sprintf("실행 파일을 찾을 수 없습니다: %s (비슷한 이름: %s)", e.Name, strings.Join(e.Suggestions, ", "))
+}
+
+// NotExecutableError 파일은 있지만 실행 권한이 없음을 나타내는 에러
+type NotExecutableError struct {
+	Path string
+}
+
+func (e *NotExecutableError) Error() string {
+	return fmt.Sprintf("실행 권한이 없습니다: %s", e.Path)
+}
+
+// RunDirError 실행 디렉토리가 없거나 디렉토리가 아님을 나타내는 에러
+type RunDirError struct {
+	Dir string
+	Err error
+}
+
+func (e *RunDirError) Error() string {
+	return fmt.Sprintf("실행 디렉토리를 사용할 수 없습니다: %s: %v", e.Dir, e.Err)
+}
+
+func (e *RunDirError) Unwrap() error {
+	return e.Err
+}
+
+var errNotDirectory = errors.New("디렉토리가 아닙니다")
+
+// maxSuggestions ExecutableNotFoundError에 포함할 비슷한 이름의 최대 개수
+const maxSuggestions = 3
+
+// Check 명령어를 실행하지 않고 실행 파일과 실행 디렉토리를 확인합니다
+// &&, ||, ;로 연결된 명령어는 모두 확인하며, 실행 파일은 명령어에 적용될 환경변수의 PATH로 찾습니다
+func (c *Cmd) Check(commandStr string) error {
+	return check(command(commandStr), c.c)
+}
+
+func check(command command, config config) error {
+	if config.err != nil {
+		return config.err
+	}
+	if command == "" {
+		return EmptyCmdError
+	}
+	if command.isShell() {
+		_, err := config.shell().process(command.script(), nil).preflight(config)
+		return err
+	}
+	if command.isWrapped() || config.LiteralArgs {
+		_, err := process{name: command.Name(), args: command.Args()}.preflight(config)
+		return err
+	}
+
+	list, err := parseCommandList(command.String(), config.escapableChars())
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return EmptyCmdError
+	}
+	for _, item := range list {
+		p, err := item.command.process(item.source, config)
+		if err != nil {
+			return err
+		}
+		if _, err := p.preflight(config); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// preflight 실행 디렉토리와 실행 파일을 확인하고 실행 파일의 경로를 찾은 process를 반환합니다
+// exec.Command와 달리 부모 프로세스가 아닌 명령어에 적용될 환경변수의 PATH를 사용합니다
+func (p process) preflight(config config) (process, error) {
+	dir := string(config.RunDir)
+	if dir != "" {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return p, &RunDirError{Dir: dir, Err: err}
+		}
+		if !info.IsDir() {
+			return p, &RunDirError{Dir: dir, Err: errNotDirectory}
+		}
+	}
+
+	env := config.environ()
+	if len(p.env) > 0 {
+		env = mergeEnv(env, p.env)
+	}
+	path, err := lookPath(p.name, dir, env)
+	if err != nil {
+		return p, err
+	}
+	p.name = path
+	return p, nil
+}
+
+// lookPath env의 PATH에서 실행 파일을 찾습니다
+// 경로 구분자가 포함된 이름은 PATH를 사용하지 않고 dir 기준으로 확인합니다
+func lookPath(name string, dir string, env []string) (string, error) {
+	pathEnv, _ := envLookup(env)("PATH")
+	if strings.ContainsRune(name, '/') || strings.ContainsRune(name, filepath.Separator) {
+		path := name
+		if !filepath.IsAbs(path) && dir != "" {
+			path = filepath.Join(dir, path)
+		}
+		// 상대 경로는 exec.Cmd가 실행 디렉토리 기준으로 해석하므로 이름을 그대로 사용합니다
+		_, ok, err := findExecutable(path, env)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return "", &ExecutableNotFoundError{Name: name}
+		}
+		return name, nil
+	}
+
+	var notExecutable error
+	for _, pathDir := range filepath.SplitList(pathEnv) {
+		if pathDir == "" {
+			continue
+		}
+		resolved, ok, err := findExecutable(filepath.Join(pathDir, name), env)
+		if ok {
+			return filepath.Abs(resolved)
+		}
+		if err != nil && notExecutable == nil {
+			notExecutable = err
+		}
+	}
+	if notExecutable != nil {
+		return "", notExecutable
+	}
+	return "", &ExecutableNotFoundError{Name: name, Suggestions: suggestExecutables(name, pathEnv)}
+}
+
+// findExecutable path가 실행할 수 있는 파일인지 확인합니다
+// 파일이 없으면 false, 파일은 있지만 실행할 수 없으면 NotExecutableError를 반환합니다
+// Windows에서 확장자가 없으면 PATHEXT의 확장자를 붙여 찾습니다
+func findExecutable(path string, env []string) (string, bool, error) {
+	candidates := []string{path}
+	if runtime.GOOS == "windows" && filepath.Ext(path) == "" {
+		pathExt, _ := envLookup(env)("PATHEXT")
+		if pathExt == "" {
+			pathExt = ".com;.exe;.bat;.cmd"
+		}
+		candidates = nil
+		for _, ext := range filepath.SplitList(pathExt) {
+			candidates = append(candidates, path+strings.ToLower(ext))
+		}
+	}
+
+	for _, candidate := range candidates {
+		info, err := os.Stat(candidate)
+		if err != nil {
+			continue
+		}
+		if info.IsDir() || (runtime.GOOS != "windows" && info.Mode()&0o111 == 0) {
+			return "", false, &NotExecutableError{Path: candidate}
+		}
+		return candidate, true, nil
+	}
+	return "", false, nil
+}
+
+// suggestExecutables PATH에서 name과 편집 거리가 가까운 실행 파일 이름을 찾습니다
+// 예: suggestExecutables("gti", "/usr/bin") -> [git]
+func suggestExecutables(name string, pathEnv string) []string {
+	maxDistance := max(1, min(2, len(name)/3))
+	distances := map[string]int{}
+	for _, dir := range filepath.SplitList(pathEnv) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			candidate := entry.Name()
+			if runtime.GOOS == "windows" {
+				candidate = strings.TrimSuffix(candidate, filepath.Ext(candidate))
+			}
+			if _, ok := distances[candidate]; ok || entry.IsDir() {
+				continue
+			}
+			if d := editDistance(name, candidate); d <= maxDistance {
+				distances[candidate] = d
+			}
+		}
+	}
+
+	suggestions := make([]string, 0, len(distances))
+	for candidate := range distances {
+		suggestions = append(suggestions, candidate)
+	}
+	slices.SortFunc(suggestions, func(a, b string) int {
+		if distances[a] != distances[b] {
+			return distances[a] - distances[b]
+		}
+		return strings.Compare(a, b)
+	})
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+	return suggestions
+}
+
+// editDistance 두 문자열의 편집 거리 (인접한 두 문자의 위치 바꿈도 한 번으로 계산)
+// 예: editDistance("gti", "git") -> 1
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(rb)]
+}
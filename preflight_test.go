@@ -0,0 +1,76 @@
+package easycmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"git", "git", 0},
+		{"gti", "git", 1},
+		{"dokcer", "docker", 1},
+		{"kubectl", "kubect", 1},
+		{"make", "cmake", 1},
+		{"go", "ls", 2},
+		{"", "abc", 3},
+	}
+
+	for _, tt := range tests {
+		if result := editDistance(tt.a, tt.b); result != tt.expected {
+			t.Errorf("editDistance(%q, %q) = %d, 기대값: %d", tt.a, tt.b, result, tt.expected)
+		}
+	}
+}
+
+func TestLookPath(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name string, perm os.FileMode) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), perm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("docker", 0o755)
+	writeFile("dockerd", 0o755)
+	writeFile("notes", 0o644)
+	env := []string{"PATH=" + dir}
+
+	t.Run("PATH에서 찾음", func(t *testing.T) {
+		path, err := lookPath("docker", "", env)
+		if err != nil || path != filepath.Join(dir, "docker") {
+			t.Errorf("lookPath() = %q, %v", path, err)
+		}
+	})
+
+	t.Run("비슷한 이름 제안", func(t *testing.T) {
+		_, err := lookPath("dokcer", "", env)
+		var notFound *ExecutableNotFoundError
+		if !errors.As(err, &notFound) {
+			t.Fatalf("ExecutableNotFoundError가 아님: %v", err)
+		}
+		if !reflect.DeepEqual(notFound.Suggestions, []string{"docker", "dockerd"}) {
+			t.Errorf("Suggestions = %v, 기대값: [docker dockerd]", notFound.Suggestions)
+		}
+	})
+
+	t.Run("실행 권한 없음", func(t *testing.T) {
+		_, err := lookPath("notes", "", env)
+		var notExecutable *NotExecutableError
+		if !errors.As(err, &notExecutable) {
+			t.Errorf("NotExecutableError가 아님: %v", err)
+		}
+	})
+
+	t.Run("실행 디렉토리 기준 상대 경로", func(t *testing.T) {
+		path, err := lookPath("./docker", dir, nil)
+		if err != nil || path != "./docker" {
+			t.Errorf("lookPath() = %q, %v", path, err)
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package easycmd
+
+import "fmt"
+
+// IOPriorityClass I/O 스케줄링 클래스 (Linux ioprio)
+type IOPriorityClass int
+
+const (
+	IOPriorityRealtime   IOPriorityClass = 1 // 실시간 (root 권한 필요)
+	IOPriorityBestEffort IOPriorityClass = 2 // 기본값
+	IOPriorityIdle       IOPriorityClass = 3 // 다른 프로세스가 I/O를 사용하지 않을 때만
+)
+
+// IOPriority 자식 프로세스의 I/O 우선순위
+type IOPriority struct {
+	Class IOPriorityClass
+	Level int // 0(높음) ~ 7(낮음), Idle 클래스에서는 무시됨
+}
+
+func (p IOPriority) String() string {
+	switch p.Class {
+	case IOPriorityRealtime:
+		return fmt.Sprintf("realtime/%d", p.Level)
+	case IOPriorityBestEffort:
+		return fmt.Sprintf("best-effort/%d", p.Level)
+	case IOPriorityIdle:
+		return "idle"
+	}
+	return fmt.Sprintf("IOPriorityClass(%d)/%d", p.Class, p.Level)
+}
+
+func validateNice(n int) error {
+	if n < -20 || n > 19 {
+		return fmt.Errorf("nice 값은 -20 ~ 19 사이여야 합니다: %d", n)
+	}
+	return nil
+}
+
+func validateIOPriority(p IOPriority) error {
+	if p.Class < IOPriorityRealtime || p.Class > IOPriorityIdle {
+		return fmt.Errorf("지원하지 않는 I/O 우선순위 클래스입니다: %d", p.Class)
+	}
+	if p.Level < 0 || p.Level > 7 {
+		return fmt.Errorf("I/O 우선순위 레벨은 0 ~ 7 사이여야 합니다: %d", p.Level)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package easycmd
+
+import (
+	"fmt"
+	"syscall"
+)
+
+const ioprioClassShift = 13
+
+// applyPriority 프로세스에 nice 값과 I/O 우선순위를 적용합니다
+// pid가 0이면 현재 스레드에 적용합니다 (HelperMain으로 다시 실행된 경우 명령어를 실행하기 직전)
+func applyPriority(pid int, req Request) error {
+	if req.Nice != nil {
+		if err := syscall.Setpriority(syscall.PRIO_PROCESS, pid, *req.Nice); err != nil {
+			return fmt.Errorf("nice 값을 적용할 수 없습니다 (%d): %w", *req.Nice, err)
+		}
+	}
+	if req.IOPriority != nil {
+		const ioprioWhoProcess = 1
+		prio := int(req.IOPriority.Class) << ioprioClassShift
+		if req.IOPriority.Class != IOPriorityIdle {
+			prio |= req.IOPriority.Level
+		}
+		_, _, errno := syscall.RawSyscall(syscall.SYS_IOPRIO_SET, ioprioWhoProcess, uintptr(pid), uintptr(prio))
+		if errno != 0 {
+			return fmt.Errorf("I/O 우선순위를 적용할 수 없습니다 (%s): %w", req.IOPriority, errno)
+		}
+	}
+	return nil
+}
+
+// hasPriority 우선순위 설정이 있는지 확인합니다
+func hasPriority(req Request) bool {
+	return req.Nice != nil || req.IOPriority != nil
+}
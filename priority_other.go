@@ -0,0 +1,8 @@
+//go:build !unix
+
+package easycmd
+
+// applyPriority nice 값과 I/O 우선순위를 지원하지 않는 OS에서는 적용하지 않습니다
+func applyPriority(pid int, req Request) error {
+	return nil
+}
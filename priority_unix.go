@@ -0,0 +1,18 @@
+//go:build unix && !linux
+
+package easycmd
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// applyPriority 시작된 프로세스에 nice 값을 적용합니다 (I/O 우선순위는 Linux 전용이므로 무시)
+func applyPriority(pid int, req Request) error {
+	if req.Nice != nil {
+		if err := syscall.Setpriority(syscall.PRIO_PROCESS, pid, *req.Nice); err != nil {
+			return fmt.Errorf("nice 값을 적용할 수 없습니다 (%d): %w", *req.Nice, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package easycmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// redirect 명령어의 입출력 리다이렉션
+type redirect struct {
+	fd     int    // 대상 파일 디스크립터 (0: stdin, 1: stdout, 2: stderr)
+	op     string // "<", ">", ">>", ">&"
+	target word   // 파일 경로, ">&"인 경우 복제할 파일 디스크립터 번호
+}
+
+// parseRedirect 리다이렉션 연산자를 해석합니다
+// 예: "2>&1" -> {fd: 2, op: ">&", target: 1}, ">>" -> {fd: 1, op: ">>"}
+func parseRedirect(op string) (redirect, error) {
+	r := redirect{fd: -1}
+	original := op
+	if op[0] >= '0' && op[0] <= '9' {
+		r.fd = int(op[0] - '0')
+		op = op[1:]
+	}
+
+	switch {
+	case op == "<":
+		r.op = "<"
+		if r.fd == -1 {
+			r.fd = 0
+		}
+	case op == ">" || op == ">>":
+		r.op = op
+		if r.fd == -1 {
+			r.fd = 1
+		}
+	case strings.HasPrefix(op, ">&"):
+		r.op = ">&"
+		r.target = word{{text: strings.TrimPrefix(op, ">&")}}
+		if r.fd == -1 {
+			r.fd = 1
+		}
+	}
+
+	if (r.op == "<") != (r.fd == 0) {
+		return r, fmt.Errorf("지원하지 않는 리다이렉션입니다: %s", original)
+	}
+	return r, nil
+}
+
+// streams 명령어에 연결할 표준 입출력
+type streams struct {
+	stdIn  io.Reader
+	stdOut io.Writer
+	stdErr io.Writer
+}
+
+// redirect 리다이렉션을 왼쪽부터 차례로 적용합니다
+// 상대 경로는 dir을 기준으로 하며, 반환된 함수로 열린 파일을 닫습니다
+func (s *streams) redirect(redirects []redirect, dir string) (func(), error) {
+	var files []*os.File
+	closeFiles := func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}
+
+	for _, r := range redirects {
+		if r.op == ">&" {
+			if r.target.String() == "2" {
+				s.setWriter(r.fd, s.stdErr)
+			} else {
+				s.setWriter(r.fd, s.stdOut)
+			}
+			continue
+		}
+
+		path := r.target.String()
+		if dir != "" && !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+
+		var f *os.File
+		var err error
+		switch r.op {
+		case "<":
+			f, err = os.Open(path)
+		case ">":
+			f, err = os.Create(path)
+		case ">>":
+			f, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+		}
+		if err != nil {
+			closeFiles()
+			return nil, fmt.Errorf("리다이렉션 파일을 열 수 없습니다: %w", err)
+		}
+		files = append(files, f)
+
+		if r.op == "<" {
+			s.stdIn = f
+		} else {
+			s.setWriter(r.fd, f)
+		}
+	}
+
+	return closeFiles, nil
+}
+
+func (s *streams) setWriter(fd int, w io.Writer) {
+	if fd == 2 {
+		s.stdErr = w
+	} else {
+		s.stdOut = w
+	}
+}
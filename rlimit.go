@@ -0,0 +1,78 @@
+package easycmd
+
+import (
+	"fmt"
+	"time"
+)
+
+// ResourceLimit 자식 프로세스에 적용할 리소스 제한 (Linux만 지원)
+type ResourceLimit struct {
+	kind  resourceKind
+	value uint64
+}
+
+type resourceKind int
+
+const (
+	resourceAddressSpace resourceKind = iota
+	resourceCPUTime
+	resourceOpenFiles
+	resourceProcesses
+	resourceCoreSize
+)
+
+// LimitAddressSpace 가상 메모리 크기 제한 (바이트, RLIMIT_AS)
+func LimitAddressSpace(bytes uint64) ResourceLimit {
+	return ResourceLimit{kind: resourceAddressSpace, value: bytes}
+}
+
+// LimitCPUTime CPU 사용 시간 제한 (초 단위로 올림, RLIMIT_CPU)
+// 초과하면 SIGXCPU로 종료되며 ResourceLimitError를 반환합니다
+func LimitCPUTime(d time.Duration) ResourceLimit {
+	return ResourceLimit{kind: resourceCPUTime, value: uint64((d + time.Second - 1) / time.Second)}
+}
+
+// LimitOpenFiles 열 수 있는 파일 디스크립터 수 제한 (RLIMIT_NOFILE)
+func LimitOpenFiles(n uint64) ResourceLimit {
+	return ResourceLimit{kind: resourceOpenFiles, value: n}
+}
+
+// LimitProcesses 실행 사용자의 프로세스 수 제한 (RLIMIT_NPROC)
+func LimitProcesses(n uint64) ResourceLimit {
+	return ResourceLimit{kind: resourceProcesses, value: n}
+}
+
+// LimitCoreSize 코어 덤프 파일 크기 제한 (바이트, 0이면 코어 덤프 비활성화, RLIMIT_CORE)
+func LimitCoreSize(bytes uint64) ResourceLimit {
+	return ResourceLimit{kind: resourceCoreSize, value: bytes}
+}
+
+func (l ResourceLimit) String() string {
+	switch l.kind {
+	case resourceAddressSpace:
+		return fmt.Sprintf("AddressSpace=%d", l.value)
+	case resourceCPUTime:
+		return fmt.Sprintf("CPUTime=%ds", l.value)
+	case resourceOpenFiles:
+		return fmt.Sprintf("OpenFiles=%d", l.value)
+	case resourceProcesses:
+		return fmt.Sprintf("Processes=%d", l.value)
+	case resourceCoreSize:
+		return fmt.Sprintf("CoreSize=%d", l.value)
+	}
+	return fmt.Sprintf("ResourceLimit(%d)=%d", l.kind, l.value)
+}
+
+// ResourceLimitError 리소스 제한을 초과하여 프로세스가 종료되었음을 나타내는 에러
+type ResourceLimitError struct {
+	Limit ResourceLimit
+	Err   error
+}
+
+func (e *ResourceLimitError) Error() string {
+	return fmt.Sprintf("리소스 제한을 초과하여 종료되었습니다 (%s): %v", e.Limit, e.Err)
+}
+
+func (e *ResourceLimitError) Unwrap() error {
+	return e.Err
+}
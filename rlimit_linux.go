@@ -0,0 +1,71 @@
+package easycmd
+
+import (
+	"errors"
+	"os/exec"
+	"syscall"
+)
+
+func (l ResourceLimit) resource() int {
+	switch l.kind {
+	case resourceAddressSpace:
+		return syscall.RLIMIT_AS
+	case resourceCPUTime:
+		return syscall.RLIMIT_CPU
+	case resourceOpenFiles:
+		return syscall.RLIMIT_NOFILE
+	case resourceProcesses:
+		return rlimitNproc
+	default:
+		return syscall.RLIMIT_CORE
+	}
+}
+
+func checkResourceLimits(limits []ResourceLimit) error {
+	return nil
+}
+
+// rlimit 적용할 soft, hard 제한
+// CPU 시간은 soft 제한에서 SIGXCPU를 받도록 hard 제한을 1초 더 크게 설정합니다
+func (l ResourceLimit) rlimit() syscall.Rlimit {
+	rlim := syscall.Rlimit{Cur: l.value, Max: l.value}
+	if l.kind == resourceCPUTime {
+		rlim.Max++
+	}
+	return rlim
+}
+
+// setrlimit resource에 rlim을 적용합니다
+// 권한이 없어 현재 hard 제한보다 크게 설정할 수 없으면 hard 제한을 유지하고 soft 제한도 그 안으로 맞춥니다
+func setrlimit(resource int, rlim syscall.Rlimit) error {
+	err := syscall.Setrlimit(resource, &rlim)
+	if !errors.Is(err, syscall.EPERM) {
+		return err
+	}
+	var current syscall.Rlimit
+	if err := syscall.Getrlimit(resource, &current); err != nil {
+		return err
+	}
+	rlim.Max = min(rlim.Max, current.Max)
+	rlim.Cur = min(rlim.Cur, rlim.Max)
+	return syscall.Setrlimit(resource, &rlim)
+}
+
+// exceededLimit 프로세스가 리소스 제한 초과로 종료되었는지 확인합니다
+// CPU 시간 초과(SIGXCPU)만 종료 원인을 확실히 알 수 있으므로 나머지 제한은 확인하지 않습니다
+func exceededLimit(err error, limits []ResourceLimit) (ResourceLimit, bool) {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return ResourceLimit{}, false
+	}
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	if !ok || !status.Signaled() || status.Signal() != syscall.SIGXCPU {
+		return ResourceLimit{}, false
+	}
+	for _, l := range limits {
+		if l.kind == resourceCPUTime {
+			return l, true
+		}
+	}
+	return ResourceLimit{}, false
+}
@@ -0,0 +1,6 @@
+//go:build linux && !mips && !mipsle && !mips64 && !mips64le && !sparc64
+
+package easycmd
+
+// rlimitNproc RLIMIT_NPROC (syscall 패키지에 정의되어 있지 않으며 아키텍처마다 값이 다름)
+const rlimitNproc = 6
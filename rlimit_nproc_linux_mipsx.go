@@ -0,0 +1,6 @@
+//go:build linux && (mips || mipsle || mips64 || mips64le)
+
+package easycmd
+
+// rlimitNproc mips 계열의 RLIMIT_NPROC
+const rlimitNproc = 8
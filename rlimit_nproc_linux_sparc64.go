@@ -0,0 +1,6 @@
+//go:build linux
+
+package easycmd
+
+// rlimitNproc sparc64의 RLIMIT_NPROC
+const rlimitNproc = 7
@@ -0,0 +1,16 @@
+//go:build !linux
+
+package easycmd
+
+import "errors"
+
+func checkResourceLimits(limits []ResourceLimit) error {
+	if len(limits) > 0 {
+		return errors.New("리소스 제한은 Linux에서만 지원합니다")
+	}
+	return nil
+}
+
+func exceededLimit(err error, limits []ResourceLimit) (ResourceLimit, bool) {
+	return ResourceLimit{}, false
+}
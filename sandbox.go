@@ -0,0 +1,78 @@
+package easycmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Sandbox 자식 프로세스를 Linux 네임스페이스로 격리하는 설정
+// 프로세스는 새 사용자, PID, 마운트, 네트워크, UTS, IPC 네임스페이스에서 실행됩니다
+// 샌드박스 안에서는 root(uid 0)로 보이지만, 호스트에서는 현재 사용자의 권한만 가집니다
+// 현재 프로그램을 네임스페이스 안에서 다시 실행하여 설정하므로 main 함수 맨 앞에서 HelperMain을 호출해야 합니다
+type Sandbox struct {
+	// BindMounts 샌드박스 안에 읽기 전용으로 바인드 마운트할 경로
+	BindMounts []BindMount
+	// Tmpfs 비어있는 개인 tmpfs를 마운트할 디렉토리 (예: /tmp)
+	Tmpfs []string
+	// Network 호스트의 네트워크 사용 (false이면 loopback만 있는 새 네트워크 네임스페이스)
+	Network bool
+	// Hostname 샌드박스의 호스트 이름 (비어있으면 sandbox)
+	Hostname string
+}
+
+// BindMount Source를 샌드박스 안의 Target에 읽기 전용으로 마운트합니다
+// 같은 경로를 지정하면 해당 경로를 읽기 전용으로 만듭니다 (예: 저장소 디렉토리)
+type BindMount struct {
+	Source string
+	Target string // 비어있으면 Source와 같은 경로, 이미 있는 경로이거나 Tmpfs 안의 경로여야 함
+}
+
+func (s Sandbox) String() string {
+	binds := make([]string, len(s.BindMounts))
+	for i, m := range s.BindMounts {
+		binds[i] = m.Source + ":" + m.target()
+	}
+	network := "none"
+	if s.Network {
+		network = "host"
+	}
+	return fmt.Sprintf("hostname=%s network=%s bind=[%s] tmpfs=[%s]",
+		s.hostname(), network, strings.Join(binds, " "), strings.Join(s.Tmpfs, " "))
+}
+
+func (s Sandbox) hostname() string {
+	if s.Hostname == "" {
+		return "sandbox"
+	}
+	return s.Hostname
+}
+
+func (m BindMount) target() string {
+	if m.Target == "" {
+		return m.Source
+	}
+	return m.Target
+}
+
+// validateSandbox 샌드박스를 지원하는 OS인지, 마운트 경로가 절대 경로인지 확인합니다
+func validateSandbox(s Sandbox, cred *Credential) error {
+	if !sandboxSupported {
+		return errors.New("샌드박스는 Linux에서만 지원합니다")
+	}
+	if cred != nil {
+		return errors.New("샌드박스와 다른 사용자로 실행하는 옵션은 함께 사용할 수 없습니다")
+	}
+	for _, m := range s.BindMounts {
+		if !filepath.IsAbs(m.Source) || !filepath.IsAbs(m.target()) {
+			return fmt.Errorf("바인드 마운트 경로는 절대 경로여야 합니다: %s:%s", m.Source, m.target())
+		}
+	}
+	for _, dir := range s.Tmpfs {
+		if !filepath.IsAbs(dir) {
+			return fmt.Errorf("tmpfs 경로는 절대 경로여야 합니다: %s", dir)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+//go:build linux
+
+package easycmd
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+const sandboxSupported = true
+
+// oPath 파일을 읽거나 쓰지 않고 경로만 가리키도록 여는 플래그 (syscall 패키지에 정의되어 있지 않음)
+const oPath = 0x200000
+
+// sandboxSetup 새 네임스페이스 안에서 마운트, 네트워크, 호스트 이름을 설정합니다
+// 마운트는 새 마운트 네임스페이스 안에서만 할 수 있으므로, HelperMain으로 다시 실행된 현재 프로그램이 명령어를 실행하기 전에 처리합니다
+func sandboxSetup(s Sandbox) error {
+	if err := syscall.Mount("", "/", "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("마운트 전파를 끌 수 없습니다: %w", err)
+	}
+	if err := syscall.Mount("proc", "/proc", "proc", syscall.MS_NOSUID|syscall.MS_NODEV|syscall.MS_NOEXEC, ""); err != nil {
+		return fmt.Errorf("/proc를 마운트할 수 없습니다: %w", err)
+	}
+	if !s.Network {
+		if err := syscall.Mount("sysfs", "/sys", "sysfs", syscall.MS_RDONLY|syscall.MS_NOSUID|syscall.MS_NODEV|syscall.MS_NOEXEC, ""); err != nil {
+			return fmt.Errorf("/sys를 마운트할 수 없습니다: %w", err)
+		}
+	}
+	// tmpfs가 바인드 마운트할 경로를 가릴 수 있으므로 바인드 마운트할 경로를 먼저 엽니다
+	sources := make([]*os.File, len(s.BindMounts))
+	for i, m := range s.BindMounts {
+		f, err := os.OpenFile(m.Source, oPath|syscall.O_CLOEXEC, 0)
+		if err != nil {
+			return fmt.Errorf("바인드 마운트할 수 없습니다 (%s:%s): %w", m.Source, m.target(), err)
+		}
+		defer f.Close()
+		sources[i] = f
+	}
+	for _, dir := range s.Tmpfs {
+		if err := syscall.Mount("tmpfs", dir, "tmpfs", syscall.MS_NOSUID|syscall.MS_NODEV, "mode=1777"); err != nil {
+			return fmt.Errorf("tmpfs를 마운트할 수 없습니다 (%s): %w", dir, err)
+		}
+	}
+	for i, m := range s.BindMounts {
+		if err := bindReadOnly(sources[i], m.target(), s.Tmpfs); err != nil {
+			return fmt.Errorf("바인드 마운트할 수 없습니다 (%s:%s): %w", m.Source, m.target(), err)
+		}
+	}
+	if !s.Network {
+		if err := loopbackUp(); err != nil {
+			return fmt.Errorf("loopback 인터페이스를 켤 수 없습니다: %w", err)
+		}
+	}
+	if err := syscall.Sethostname([]byte(s.hostname())); err != nil {
+		return fmt.Errorf("호스트 이름을 설정할 수 없습니다: %w", err)
+	}
+	return nil
+}
+
+// lockedMountFlags 사용자 네임스페이스에서 다시 마운트할 때 유지해야 하는 플래그 (statfs 플래그 -> 마운트 플래그)
+var lockedMountFlags = []struct{ statfs, mount uintptr }{
+	{0x2, syscall.MS_NOSUID},
+	{0x4, syscall.MS_NODEV},
+	{0x8, syscall.MS_NOEXEC},
+	{0x400, syscall.MS_NOATIME},
+	{0x800, syscall.MS_NODIRATIME},
+	{0x1000, syscall.MS_RELATIME},
+}
+
+// bindReadOnly 열어둔 source를 target에 바인드 마운트한 뒤 읽기 전용으로 다시 마운트합니다
+// target이 없으면 tmpfs 안인 경우에만 source와 같은 종류(디렉토리, 파일)로 만듭니다
+func bindReadOnly(source *os.File, target string, tmpfs []string) error {
+	info, err := source.Stat()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Lstat(target); errors.Is(err, os.ErrNotExist) {
+		if err := createMountPoint(target, info.IsDir(), tmpfs); err != nil {
+			return err
+		}
+	}
+	path := fmt.Sprintf("/proc/self/fd/%d", source.Fd())
+	if err := syscall.Mount(path, target, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+		return err
+	}
+
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(target, &st); err != nil {
+		return err
+	}
+	flags := uintptr(syscall.MS_BIND | syscall.MS_REMOUNT | syscall.MS_RDONLY)
+	for _, f := range lockedMountFlags {
+		if uintptr(st.Flags)&f.statfs != 0 {
+			flags |= f.mount
+		}
+	}
+	return syscall.Mount("", target, "", flags, "")
+}
+
+// createMountPoint 없는 마운트 지점을 만듭니다
+// 마운트 네임스페이스와 관계없이 호스트의 파일 시스템이 바뀌므로, 샌드박스의 tmpfs 안에서만 만들 수 있습니다
+func createMountPoint(target string, dir bool, tmpfs []string) error {
+	if !isAllowedDir(target, tmpfs) {
+		return fmt.Errorf("마운트 지점이 없습니다 (없는 경로는 Tmpfs 안에만 만들 수 있습니다): %s", target)
+	}
+	if dir {
+		return os.MkdirAll(target, 0o755)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// loopbackUp 새 네트워크 네임스페이스의 lo 인터페이스를 켭니다 (SIOCSIFFLAGS)
+func loopbackUp() error {
+	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_DGRAM|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer syscall.Close(fd)
+
+	var ifr [40]byte // struct ifreq: 인터페이스 이름(16바이트) + 플래그
+	copy(ifr[:syscall.IFNAMSIZ], "lo")
+	binary.NativeEndian.PutUint16(ifr[syscall.IFNAMSIZ:], syscall.IFF_UP|syscall.IFF_RUNNING)
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), syscall.SIOCSIFFLAGS, uintptr(unsafe.Pointer(&ifr[0])))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// sandboxAttr 새 사용자, PID, 마운트, UTS, IPC (Network가 false이면 네트워크) 네임스페이스에서 시작하도록 attr을 설정합니다
+// 샌드박스 안의 root(uid 0)를 현재 사용자에 매핑합니다
+func sandboxAttr(attr *syscall.SysProcAttr, sandbox Sandbox) *syscall.SysProcAttr {
+	if attr == nil {
+		attr = &syscall.SysProcAttr{}
+	}
+	attr.Cloneflags |= syscall.CLONE_NEWUSER | syscall.CLONE_NEWPID | syscall.CLONE_NEWNS |
+		syscall.CLONE_NEWUTS | syscall.CLONE_NEWIPC
+	if !sandbox.Network {
+		attr.Cloneflags |= syscall.CLONE_NEWNET
+	}
+	attr.UidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getuid(), Size: 1}}
+	attr.GidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getgid(), Size: 1}}
+	attr.GidMappingsEnableSetgroups = false
+	return attr
+}
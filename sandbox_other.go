@@ -0,0 +1,5 @@
+//go:build !linux
+
+package easycmd
+
+const sandboxSupported = false
@@ -0,0 +1,38 @@
+package easycmd
+
+import "testing"
+
+func TestValidateSandbox(t *testing.T) {
+	if !sandboxSupported {
+		t.Skip("샌드박스는 Linux에서만 지원합니다")
+	}
+	tests := []struct {
+		name     string
+		sandbox  Sandbox
+		cred     *Credential
+		hasError bool
+	}{
+		{name: "기본 설정", sandbox: Sandbox{}},
+		{name: "바인드 마운트와 tmpfs", sandbox: Sandbox{BindMounts: []BindMount{{Source: "/usr"}, {Source: "/src", Target: "/work"}}, Tmpfs: []string{"/tmp"}}},
+		{name: "상대 경로 바인드 마운트", sandbox: Sandbox{BindMounts: []BindMount{{Source: "src", Target: "/work"}}}, hasError: true},
+		{name: "상대 경로 tmpfs", sandbox: Sandbox{Tmpfs: []string{"tmp"}}, hasError: true},
+		{name: "다른 사용자로 실행", sandbox: Sandbox{}, cred: &Credential{UID: 1000, GID: 1000}, hasError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSandbox(tt.sandbox, tt.cred)
+			if (err != nil) != tt.hasError {
+				t.Errorf("validateSandbox() = %v, 에러 기대: %v", err, tt.hasError)
+			}
+		})
+	}
+}
+
+func TestSandboxString(t *testing.T) {
+	sandbox := Sandbox{BindMounts: []BindMount{{Source: "/usr"}, {Source: "/src", Target: "/work"}}, Tmpfs: []string{"/tmp"}}
+	expected := "hostname=sandbox network=none bind=[/usr:/usr /src:/work] tmpfs=[/tmp]"
+	if result := sandbox.String(); result != expected {
+		t.Errorf("String() = %q, 기대값: %q", result, expected)
+	}
+}
@@ -0,0 +1,66 @@
+package easycmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// RunScript fsys의 스크립트 파일을 실행합니다 (embed.FS 등)
+// 스크립트는 임시 디렉토리에 실행 권한으로 저장한 뒤 실행하며, 실행이 끝나면 삭제합니다
+// 인터프리터는 shebang(#!)으로 정하고, shebang이 없으면 RunShell과 같은 쉘을 사용합니다
+// 예: cmd.RunScript(scripts, "scripts/deploy.sh", "prod") -> bash /tmp/easycmd-script-123/deploy.sh prod
+func (c *Cmd) RunScript(fsys fs.FS, name string, args ...string) error {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return fmt.Errorf("스크립트를 읽을 수 없습니다: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "easycmd-script-*")
+	if err != nil {
+		return fmt.Errorf("스크립트 임시 디렉토리 생성 실패: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, path.Base(name))
+	if err := os.WriteFile(file, data, 0o700); err != nil {
+		return fmt.Errorf("스크립트 임시 파일 생성 실패: %w", err)
+	}
+	// 다른 사용자로 실행하는 경우 임시 디렉토리(0700)와 스크립트를 실행 사용자가 읽을 수 있어야 합니다
+	if err := chownForCredential(c.c.Credential, dir, file); err != nil {
+		return err
+	}
+
+	interpreter, ok := parseShebang(data)
+	if !ok {
+		shell := c.c.shell()
+		interpreter = append([]string{shell.Path}, shell.Flags...)
+	}
+	p := process{
+		name: interpreter[0],
+		args: append(append(interpreter[1:len(interpreter):len(interpreter)], file), args...),
+		mode: modeShell,
+	}
+	p.display = strings.Join(append([]string{p.name}, p.args...), " ")
+	return runProcess(context.Background(), p, c.c)
+}
+
+// parseShebang 스크립트 첫 줄의 shebang에서 인터프리터와 인수를 가져옵니다
+// 예: "#!/usr/bin/env bash\n..." -> [/usr/bin/env bash], true
+func parseShebang(data []byte) ([]string, bool) {
+	line, _, _ := bytes.Cut(data, []byte("\n"))
+	rest, ok := bytes.CutPrefix(line, []byte("#!"))
+	if !ok {
+		return nil, false
+	}
+	interpreter := strings.Fields(string(rest))
+	if len(interpreter) == 0 {
+		return nil, false
+	}
+	return interpreter, true
+}
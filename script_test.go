@@ -0,0 +1,30 @@
+package easycmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseShebang(t *testing.T) {
+	tests := []struct {
+		name     string
+		script   string
+		expected []string
+		ok       bool
+	}{
+		{name: "경로", script: "#!/bin/sh\necho hi\n", expected: []string{"/bin/sh"}, ok: true},
+		{name: "env 사용", script: "#!/usr/bin/env bash\necho hi\n", expected: []string{"/usr/bin/env", "bash"}, ok: true},
+		{name: "인수와 CRLF", script: "#! /bin/bash -e\r\necho hi\r\n", expected: []string{"/bin/bash", "-e"}, ok: true},
+		{name: "shebang 없음", script: "echo hi\n", ok: false},
+		{name: "빈 shebang", script: "#!\necho hi\n", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := parseShebang([]byte(tt.script))
+			if ok != tt.ok || !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("parseShebang() = %v, %v, 기대값: %v, %v", result, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package easycmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Shell RunShell 계열 메서드에서 스크립트를 실행할 쉘
+type Shell struct {
+	Path        string   // 실행 파일 이름 또는 경로
+	Flags       []string // CommandFlag 앞에 붙는 옵션 (예: -e, -o pipefail, -l)
+	CommandFlag string   // 스크립트를 전달하는 옵션 (예: -c, -Command)
+}
+
+var (
+	ShellSh   = Shell{Path: "sh", CommandFlag: "-c"}
+	ShellBash = Shell{Path: "bash", CommandFlag: "-c"}
+	ShellZsh  = Shell{Path: "zsh", CommandFlag: "-c"}
+	ShellDash = Shell{Path: "dash", CommandFlag: "-c"}
+	ShellFish = Shell{Path: "fish", CommandFlag: "-c"}
+	ShellPwsh = Shell{Path: "pwsh", Flags: []string{"-NoProfile"}, CommandFlag: "-Command"}
+)
+
+// fallbackShells 쉘을 지정하지 않았을 때 순서대로 찾아 사용할 쉘
+var fallbackShells = []Shell{ShellBash, ShellSh}
+
+// newShell path의 실행 파일 이름으로 스크립트 전달 옵션을 정해 Shell을 만듭니다
+// 예: newShell("/usr/bin/pwsh") -> CommandFlag: -Command, newShell("/bin/ash") -> CommandFlag: -c
+func newShell(path string, flags []string) Shell {
+	commandFlag := "-c"
+	if isPowershellName(shellName(path)) {
+		commandFlag = "-Command"
+	}
+	return Shell{Path: path, Flags: flags, CommandFlag: commandFlag}
+}
+
+// shellName 확장자를 제외한 쉘 실행 파일 이름
+// 예: shellName("/usr/bin/zsh") -> "zsh", shellName("powershell.exe") -> "powershell"
+func shellName(path string) string {
+	return strings.TrimSuffix(strings.ToLower(filepath.Base(path)), ".exe")
+}
+
+func isPowershellName(name string) bool {
+	return name == "pwsh" || name == "powershell"
+}
+
+// discoverShell fallbackShells 중 PATH에서 처음 찾은 쉘을 반환합니다 (없으면 bash)
+func discoverShell() Shell {
+	for _, s := range fallbackShells {
+		if _, err := exec.LookPath(s.Path); err == nil {
+			return s
+		}
+	}
+	return ShellBash
+}
+
+// withFlags flags를 추가한 Shell을 반환합니다
+func (s Shell) withFlags(flags ...string) Shell {
+	s.Flags = append(append([]string{}, s.Flags...), flags...)
+	return s
+}
+
+// args 스크립트를 실행할 인수 목록
+// params는 스크립트의 위치 인수($1, $2, ...)로 전달하며 $0은 쉘 이름입니다
+// 예: bash -e -c script -> [-e -c script], bash -c script a b -> [-c script bash a b]
+func (s Shell) args(script string, params ...string) []string {
+	args := append(append([]string{}, s.Flags...), s.CommandFlag)
+	name := shellName(s.Path)
+	switch {
+	case len(params) == 0:
+		return append(args, script)
+	case isPowershellName(name):
+		return append(args, powershellArgsScript(script, params))
+	case name == "fish":
+		// fish는 $0 없이 $argv로 전달
+		return append(append(args, script), params...)
+	default:
+		return append(append(args, script, s.Path), params...)
+	}
+}
+
+// powershellArgsScript 스크립트를 스크립트 블록으로 감싸고 params를 $args로 전달합니다
+// -Command에 문자열로 전달한 스크립트는 -args를 사용할 수 없으므로 각 값을 quotePowershell로 인용부호 처리하여 스크립트 블록의 인수로 전달합니다
+// 값이 명령어 문자열에 포함되므로 quotePowershell이 '와 유니코드 작은따옴표(‘ ’ ‚ ‛)를 모두 이스케이프해야 안전합니다
+// 예: powershellArgsScript("echo $args[0]", ["a b"]) -> & {echo $args[0]\n} 'a b'
+func powershellArgsScript(script string, params []string) string {
+	quoted := make([]string, len(params))
+	for i, p := range params {
+		quoted[i] = quotePowershell(p)
+	}
+	return "& {" + script + "\n} " + strings.Join(quoted, " ")
+}
+
+// display 로그에 표시할 명령어 문자열
+// 예: bash -c echo hello
+func (s Shell) display(script string, params ...string) string {
+	return strings.Join(append([]string{s.Path}, s.args(script, params...)...), " ")
+}
+
+// shell 설정된 쉘 (지정하지 않았으면 bash, bash가 없으면 sh)에 WithShellFlags 옵션을 적용합니다
+func (c config) shell() Shell {
+	if c.Shell == nil {
+		return discoverShell().withFlags(c.ShellFlags...)
+	}
+	return c.Shell.withFlags(c.ShellFlags...)
+}
+
+// quoting 스크립트에 값을 삽입할 때 사용할 인용부호 처리 방식
+func (s Shell) quoting() quoting {
+	if isPowershellName(shellName(s.Path)) {
+		return powershellQuoting
+	}
+	return posixShellQuoting
+}
+
+// runShell 설정된 쉘로 params를 위치 인수로 전달하여 스크립트를 실행합니다
+// WithShellStrict 옵션이 있으면 엄격 모드로 실행하고, 실패한 줄 번호를 알 수 있으면 ScriptError를 반환합니다
+func runShell(parent context.Context, script string, params []string, config config) error {
+	shell := config.shell()
+	if !config.ShellStrict {
+		return runProcess(parent, shell.process(script, params), config)
+	}
+
+	lineFile, err := os.CreateTemp("", "easycmd-line-*")
+	if err != nil {
+		return fmt.Errorf("엄격 모드 준비 실패: %w", err)
+	}
+	lineFile.Close()
+	defer os.Remove(lineFile.Name())
+	// 다른 사용자로 실행하는 경우 실행 사용자가 줄 번호 파일(0600)에 쓸 수 있어야 합니다
+	if err := chownForCredential(config.Credential, lineFile.Name()); err != nil {
+		return err
+	}
+
+	strict, err := shell.strictScript(script, lineFile.Name())
+	if err != nil {
+		return err
+	}
+	err = runProcess(parent, shell.process(strict, params), config)
+	if err != nil {
+		if line := readFailedLine(lineFile.Name()); line > 0 {
+			return &ScriptError{Line: line, Err: err}
+		}
+	}
+	return err
+}
+
+func (s Shell) process(script string, params []string) process {
+	return process{
+		display: s.display(script, params...),
+		name:    s.Path,
+		args:    s.args(script, params...),
+		mode:    modeShell,
+	}
+}
+
+// strictScript 쉘에 맞는 엄격 모드 설정을 script 앞에 추가합니다
+// 실패한 줄 번호는 ERR trap (PowerShell은 trap 블록)으로 lineFile에 기록하며, 줄 번호를 지원하지 않는 쉘 (sh, dash)은 기록하지 않습니다
+// 스크립트의 줄 번호가 바뀌지 않도록 설정은 스크립트의 첫 줄 앞에 같은 줄로 추가합니다
+func (s Shell) strictScript(script string, lineFile string) (string, error) {
+	switch name := shellName(s.Path); {
+	case name == "bash":
+		// bash는 -E(errtrace)가 있어야 함수 안에서 실패한 경우에도 ERR trap을 실행합니다
+		trap := "echo $LINENO > " + quotePosix(lineFile)
+		return "set -Eeuo pipefail; trap " + quotePosix(trap) + " ERR; " + script, nil
+	case name == "zsh" || name == "ksh":
+		// zsh와 ksh는 별도 옵션 없이 함수 안에서도 ERR trap을 실행합니다
+		trap := "echo $LINENO > " + quotePosix(lineFile)
+		return "set -euo pipefail; trap " + quotePosix(trap) + " ERR; " + script, nil
+	case isPowershellName(name):
+		trap := "trap { Set-Content -LiteralPath " + quotePowershell(lineFile) + " -Value $_.InvocationInfo.ScriptLineNumber; break }; "
+		return "$ErrorActionPreference = 'Stop'; $PSNativeCommandUseErrorActionPreference = $true; Set-StrictMode -Version Latest; " + trap + script, nil
+	case name == "fish":
+		return "", fmt.Errorf("%s 쉘은 엄격 모드를 지원하지 않습니다", s.Path)
+	default:
+		return "set -eu; " + script, nil
+	}
+}
+
+// readFailedLine lineFile에 기록된 실패한 줄 번호 (기록되지 않았으면 0)
+func readFailedLine(lineFile string) int {
+	data, err := os.ReadFile(lineFile)
+	if err != nil {
+		return 0
+	}
+	line, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0
+	}
+	return line
+}
+
+// ScriptError 엄격 모드로 실행한 쉘 스크립트가 실패한 줄 번호와 에러
+type ScriptError struct {
+	Line int
+	Err  error
+}
+
+func (e *ScriptError) Error() string {
+	return fmt.Sprintf("쉘 스크립트 %d번째 줄에서 실패했습니다: %v", e.Line, e.Err)
+}
+
+func (e *ScriptError) Unwrap() error {
+	return e.Err
+}
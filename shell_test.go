@@ -0,0 +1,147 @@
+package easycmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNewShell(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		flags    []string
+		expected []string
+	}{
+		{name: "sh", path: "/bin/sh", expected: []string{"-c", "script"}},
+		{name: "옵션 포함", path: "bash", flags: []string{"-e", "-o", "pipefail"}, expected: []string{"-e", "-o", "pipefail", "-c", "script"}},
+		{name: "pwsh", path: "/usr/bin/pwsh", expected: []string{"-Command", "script"}},
+		{name: "powershell.exe", path: "powershell.exe", expected: []string{"-Command", "script"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := newShell(tt.path, tt.flags).args("script")
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("args() = %v, 기대값: %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestShellArgsWithParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		shell    Shell
+		expected []string
+	}{
+		{name: "bash", shell: ShellBash, expected: []string{"-c", "script", "bash", "a b", "c"}},
+		{name: "fish", shell: ShellFish, expected: []string{"-c", "script", "a b", "c"}},
+		{name: "pwsh", shell: ShellPwsh, expected: []string{"-NoProfile", "-Command", "& {script\n} 'a b' c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.shell.args("script", "a b", "c")
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("args() = %q, 기대값: %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPowershellArgsScript(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   []string
+		expected string
+	}{
+		{name: "작은따옴표", params: []string{"it's"}, expected: "& {script\n} 'it''s'"},
+		{name: "유니코드 작은따옴표", params: []string{"\u2019; Remove-Item x; \u2018"}, expected: "& {script\n} '\u2019\u2019; Remove-Item x; \u2018\u2018'"},
+		{name: "매개변수 형태의 값", params: []string{"-Force", "a"}, expected: "& {script\n} '-Force' a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := powershellArgsScript("script", tt.params)
+			if result != tt.expected {
+				t.Errorf("powershellArgsScript() = %q, 기대값: %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		name     string
+		shell    Shell
+		expected string
+	}{
+		{name: "bash", shell: ShellBash, expected: `'it'"'"'s'`},
+		{name: "pwsh", shell: ShellPwsh, expected: `'it''s'`},
+		{name: "powershell.exe", shell: newShell("powershell.exe", nil), expected: `'it''s'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.shell.quoting().quote("it's"); result != tt.expected {
+				t.Errorf("quote() = %q, 기대값: %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfigShell(t *testing.T) {
+	zsh := ShellZsh
+	c := config{Shell: &zsh, ShellFlags: []string{"-l"}}
+
+	if result := c.shell().display("echo hi"); result != "zsh -l -c echo hi" {
+		t.Errorf("display() = %q, 기대값: %q", result, "zsh -l -c echo hi")
+	}
+	if len(ShellZsh.Flags) != 0 {
+		t.Errorf("프리셋이 변경되지 않아야 함: %v", ShellZsh.Flags)
+	}
+}
+
+func TestDefaultShellWithExecutor(t *testing.T) {
+	// Executor를 지정해도 기본 쉘은 PATH에서 찾아야 함 (sh만 있는 환경)
+	binDir := t.TempDir()
+	if err := os.Symlink("/bin/sh", filepath.Join(binDir, "sh")); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir)
+
+	c := config{Executor: ExecExecutor{}}
+	c.fillDefault()
+
+	if c.Shell.Path != "sh" {
+		t.Errorf("Shell.Path = %q, 기대값: %q", c.Shell.Path, "sh")
+	}
+}
+
+func TestStrictScript(t *testing.T) {
+	tests := []struct {
+		name     string
+		shell    Shell
+		expected string
+		wantErr  bool
+	}{
+		{name: "bash", shell: ShellBash, expected: `set -Eeuo pipefail; trap 'echo $LINENO > /tmp/line' ERR; echo hi`},
+		{name: "sh", shell: ShellSh, expected: "set -eu; echo hi"},
+		{name: "경로로 지정한 zsh", shell: newShell("/usr/bin/zsh", nil), expected: `set -euo pipefail; trap 'echo $LINENO > /tmp/line' ERR; echo hi`},
+		{name: "fish", shell: ShellFish, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.shell.strictScript("echo hi", "/tmp/line")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("strictScript() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("strictScript() = %q, 기대값: %q", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !unix
+
+package easycmd
+
+import (
+	"errors"
+	"syscall"
+)
+
+const credentialSupported = false
+
+func sysProcAttr(req Request) (*syscall.SysProcAttr, error) {
+	if req.Credential != nil {
+		return nil, errors.New("다른 사용자로 실행하는 기능을 지원하지 않는 OS입니다")
+	}
+	return nil, nil
+}
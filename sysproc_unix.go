@@ -0,0 +1,21 @@
+//go:build unix
+
+package easycmd
+
+import "syscall"
+
+const credentialSupported = true
+
+// sysProcAttr Request의 실행 사용자 설정을 SysProcAttr로 변환합니다
+func sysProcAttr(req Request) (*syscall.SysProcAttr, error) {
+	if req.Credential == nil {
+		return nil, nil
+	}
+	return &syscall.SysProcAttr{
+		Credential: &syscall.Credential{
+			Uid:    req.Credential.UID,
+			Gid:    req.Credential.GID,
+			Groups: req.Credential.Groups,
+		},
+	}, nil
+}
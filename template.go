@@ -0,0 +1,312 @@
+package easycmd
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"unicode/utf8"
+)
+
+const templateQuoteFunc = "easycmdQuote"
+
+// templatePlaceholder 템플릿 값이 들어갈 자리를 나타내는 구분자
+// 값은 템플릿을 실행한 뒤 자리 앞의 인용부호 상태에 맞게 인용부호 처리하여 넣습니다
+const templatePlaceholder = '\x00'
+
+// rawValue raw 함수로 인용부호 처리를 명시적으로 제외한 값
+type rawValue string
+
+// RunTemplate text/template으로 명령어를 만들어 실행합니다
+// 템플릿에 삽입되는 모든 값은 하나의 인수가 되도록 자동으로 인용부호 처리되며, {{raw .Value}}로 제외할 수 있습니다
+// "{{.Value}}"처럼 인용부호 안에 삽입한 값은 인용부호 안의 문자열 일부가 되도록 처리합니다
+func (c *Cmd) RunTemplate(tmpl string, data any) error {
+	commandStr, err := renderTemplate(tmpl, data, commandQuoting)
+	if err != nil {
+		return err
+	}
+	return c.Run(commandStr)
+}
+
+// RunShellTemplate 템플릿으로 만든 스크립트를 RunShell과 같이 실행합니다
+// 값은 설정된 쉘에 맞게 인용부호 처리됩니다 (pwsh, powershell은 PowerShell 방식, 그 외는 POSIX 방식)
+func (c *Cmd) RunShellTemplate(tmpl string, data any) error {
+	commandStr, err := renderTemplate(tmpl, data, c.c.shell().quoting())
+	if err != nil {
+		return err
+	}
+	return c.RunShell(commandStr)
+}
+
+func (c *Cmd) RunPowershellTemplate(tmpl string, data any) error {
+	commandStr, err := renderTemplate(tmpl, data, powershellQuoting)
+	if err != nil {
+		return err
+	}
+	return c.RunPowershell(commandStr)
+}
+
+// renderTemplate 모든 출력 액션 뒤에 값의 자리를 표시하는 함수를 붙여 템플릿을 실행한 뒤 (html/template의 자동 이스케이프와 같은 방식)
+// 각 자리 앞의 인용부호 상태에 맞게 q로 인용부호 처리한 값을 넣습니다
+func renderTemplate(tmpl string, data any, q quoting) (string, error) {
+	if strings.ContainsRune(tmpl, templatePlaceholder) {
+		return "", errors.New("명령어 템플릿에 NUL 문자를 사용할 수 없습니다")
+	}
+
+	var values []any
+	// 누락된 값이 "<no value>"로 명령어에 들어가지 않도록 에러로 처리
+	t, err := template.New("command").Option("missingkey=error").Funcs(template.FuncMap{
+		"raw": func(value any) rawValue {
+			return rawValue(fmt.Sprint(value))
+		},
+		templateQuoteFunc: func(value any) (string, error) {
+			if raw, ok := value.(rawValue); ok {
+				if strings.ContainsRune(string(raw), templatePlaceholder) {
+					return "", errors.New("raw 값에 NUL 문자를 사용할 수 없습니다")
+				}
+				return string(raw), nil
+			}
+			values = append(values, value)
+			return fmt.Sprintf("%c%d%c", templatePlaceholder, len(values)-1, templatePlaceholder), nil
+		},
+	}).Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("명령어 템플릿을 해석할 수 없습니다: %w", err)
+	}
+
+	for _, associated := range t.Templates() {
+		if associated.Tree != nil {
+			addQuoteFunc(associated.Tree, associated.Tree.Root)
+		}
+	}
+
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("명령어 템플릿을 실행할 수 없습니다: %w", err)
+	}
+	return q.fill(b.String(), values), nil
+}
+
+// addQuoteFunc 출력하는 모든 액션의 파이프라인 끝에 quote 함수를 추가합니다
+func addQuoteFunc(tree *parse.Tree, node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			addQuoteFunc(tree, child)
+		}
+	case *parse.ActionNode:
+		// {{$x := .Value}}처럼 변수를 선언하는 액션은 출력하지 않으므로 제외
+		if len(n.Pipe.Decl) > 0 {
+			return
+		}
+		n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+			NodeType: parse.NodeCommand,
+			Pos:      n.Pos,
+			Args:     []parse.Node{parse.NewIdentifier(templateQuoteFunc).SetTree(tree).SetPos(n.Pos)},
+		})
+	case *parse.IfNode:
+		addQuoteFunc(tree, n.List)
+		addQuoteFunc(tree, n.ElseList)
+	case *parse.RangeNode:
+		addQuoteFunc(tree, n.List)
+		addQuoteFunc(tree, n.ElseList)
+	case *parse.WithNode:
+		addQuoteFunc(tree, n.List)
+		addQuoteFunc(tree, n.ElseList)
+	}
+}
+
+// quoting 명령어 문법에 따라 템플릿 값을 인용부호 처리하는 방식
+type quoting struct {
+	quote         func(string) string              // 인용부호 밖의 값을 하나의 인수로 만듭니다
+	inQuote       func(s string, kind rune) string // 인용부호(kind: '\'', '"') 안의 값을 문자열 일부로 만듭니다
+	quoteKind     func(r rune) rune                // 인용부호 문자의 종류 ('\'', '"', 인용부호가 아니면 0)
+	escape        rune                             // 다음 문자를 이스케이프하는 문자
+	escapeInQuote bool                             // 큰따옴표 안에서도 escape를 인식하는지 여부
+}
+
+// commandQuoting Run의 명령어 파서 문법 (인용부호 안과 Windows에서는 백슬래시 이스케이프를 인식하지 않음)
+var commandQuoting = quoting{quote: quotePosix, inQuote: inQuotePosix, quoteKind: posixQuoteKind, escape: commandEscape()}
+
+// posixShellQuoting bash, sh 등 POSIX 쉘 문법
+var posixShellQuoting = quoting{quote: quotePosix, inQuote: inQuotePosix, quoteKind: posixQuoteKind, escape: '\\', escapeInQuote: true}
+
+// powershellQuoting PowerShell 문법
+var powershellQuoting = quoting{quote: quotePowershell, inQuote: inQuotePowershell, quoteKind: powershellQuoteKind, escape: '`', escapeInQuote: true}
+
+// fill rendered의 각 값 자리를 앞의 인용부호 상태에 맞게 인용부호 처리한 values로 바꿉니다
+// 예: echo "x {{.}}" (값: a b) -> echo "x "'a b'""
+func (q quoting) fill(rendered string, values []any) string {
+	var b strings.Builder
+	var open rune
+	escaped := false
+	for i := 0; i < len(rendered); i++ {
+		if rendered[i] == templatePlaceholder {
+			end := strings.IndexByte(rendered[i+1:], templatePlaceholder) + i + 1
+			index, _ := strconv.Atoi(rendered[i+1 : end])
+			if open == 0 {
+				b.WriteString(quoteTemplateValue(values[index], q.quote))
+			} else {
+				b.WriteString(q.inQuote(templateString(values[index]), open))
+			}
+			i = end
+			escaped = false
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(rendered[i:])
+		b.WriteString(rendered[i : i+size])
+		i += size - 1
+
+		switch kind := q.quoteKind(r); {
+		case escaped:
+			escaped = false
+		case r == q.escape && (open == 0 || open == '"' && q.escapeInQuote):
+			escaped = true
+		case open == 0 && kind != 0:
+			open = kind
+		case open != 0 && kind == open:
+			open = 0
+		}
+	}
+	return b.String()
+}
+
+// quoteTemplateValue 값을 인용부호 처리합니다
+// 문자열 슬라이스 등 목록은 각 요소를 하나의 인수로 처리하여 공백으로 연결합니다
+func quoteTemplateValue(value any, quote func(string) string) string {
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		quoted := make([]string, v.Len())
+		for i := range quoted {
+			quoted[i] = quoteTemplateValue(v.Index(i).Interface(), quote)
+		}
+		return strings.Join(quoted, " ")
+	}
+	return quote(fmt.Sprint(value))
+}
+
+// templateString 인용부호 안에 넣을 값의 문자열 (목록은 각 요소를 공백으로 연결한 하나의 문자열)
+func templateString(value any) string {
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		strs := make([]string, v.Len())
+		for i := range strs {
+			strs[i] = templateString(v.Index(i).Interface())
+		}
+		return strings.Join(strs, " ")
+	}
+	return fmt.Sprint(value)
+}
+
+// quotePosix 직접 실행 파서와 bash에서 하나의 인수로 해석되도록 인용부호 처리합니다
+// 예: quotePosix("it's") -> 'it'"'"'s'
+func quotePosix(s string) string {
+	if s != "" && isSafeArg(s, "_-./:,+%@") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
+
+func commandEscape() rune {
+	if runtime.GOOS == "windows" {
+		return 0
+	}
+	return '\\'
+}
+
+// inQuotePosix 인용부호를 닫고 quotePosix로 처리한 값을 붙인 뒤 다시 열어, 값이 인용부호 안 문자열의 일부가 되도록 합니다
+// 예: inQuotePosix("a b", '"') -> "'a b'"
+func inQuotePosix(s string, kind rune) string {
+	return string(kind) + quotePosix(s) + string(kind)
+}
+
+func posixQuoteKind(r rune) rune {
+	if isQuoteChar(r) {
+		return r
+	}
+	return 0
+}
+
+// quotePowershell PowerShell에서 하나의 인수로 해석되도록 인용부호 처리합니다
+// -로 시작하는 값은 매개변수로 해석되지 않도록 항상 인용부호로 감쌉니다
+// PowerShell은 ' 외에 ‘ ’ ‚ ‛ 도 작은따옴표로 인식하므로 값 안의 이 문자들을 모두 두 번 써서 이스케이프합니다
+func quotePowershell(s string) string {
+	if s != "" && !strings.HasPrefix(s, "-") && isSafeArg(s, "_-./:") {
+		return s
+	}
+	var b strings.Builder
+	b.WriteRune('\'')
+	for _, r := range s {
+		if isPowershellSingleQuote(r) {
+			b.WriteRune(r)
+		}
+		b.WriteRune(r)
+	}
+	b.WriteRune('\'')
+	return b.String()
+}
+
+// inQuotePowershell 작은따옴표 안에서는 작은따옴표 문자를 두 번 쓰고, 큰따옴표 안에서는 특수 문자 앞에 `를 붙여 이스케이프합니다
+// 예: inQuotePowershell("it's", '\”) -> it”s, inQuotePowershell("$x", '"') -> `$x
+func inQuotePowershell(s string, kind rune) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case kind == '\'' && isPowershellSingleQuote(r):
+			b.WriteRune(r)
+		case kind == '"' && (r == '`' || r == '$' || isPowershellDoubleQuote(r)):
+			b.WriteRune('`')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func powershellQuoteKind(r rune) rune {
+	switch {
+	case isPowershellSingleQuote(r):
+		return '\''
+	case isPowershellDoubleQuote(r):
+		return '"'
+	}
+	return 0
+}
+
+// isPowershellSingleQuote PowerShell이 작은따옴표로 인식하는 문자인지 확인
+// 예: ', ‘(U+2018), ’(U+2019), ‚(U+201A), ‛(U+201B)
+func isPowershellSingleQuote(r rune) bool {
+	switch r {
+	case '\'', '\u2018', '\u2019', '\u201A', '\u201B':
+		return true
+	}
+	return false
+}
+
+// isPowershellDoubleQuote PowerShell이 큰따옴표로 인식하는 문자인지 확인
+// 예: ", “(U+201C), ”(U+201D), „(U+201E)
+func isPowershellDoubleQuote(r rune) bool {
+	switch r {
+	case '"', '\u201C', '\u201D', '\u201E':
+		return true
+	}
+	return false
+}
+
+// isSafeArg 영문자, 숫자, safeChars로만 이루어져 인용부호 없이 사용해도 되는지 확인
+func isSafeArg(s string, safeChars string) bool {
+	for _, r := range s {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || strings.ContainsRune(safeChars, r) {
+			continue
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,189 @@
+package easycmd
+
+import "testing"
+
+func TestRenderTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		tmpl     string
+		data     any
+		quoting  quoting
+		expected string
+	}{
+		{
+			name:     "안전한 값은 그대로",
+			tmpl:     "git checkout {{.}}",
+			data:     "release/v1.2",
+			quoting:  commandQuoting,
+			expected: "git checkout release/v1.2",
+		},
+		{
+			name:     "공백이 있는 값",
+			tmpl:     "git commit -m {{.}}",
+			data:     "fix bug",
+			quoting:  commandQuoting,
+			expected: "git commit -m 'fix bug'",
+		},
+		{
+			name:     "작은따옴표가 있는 값",
+			tmpl:     "echo {{.}}",
+			data:     "it's",
+			quoting:  commandQuoting,
+			expected: `echo 'it'"'"'s'`,
+		},
+		{
+			name:     "명령어 삽입 시도",
+			tmpl:     "echo {{.}}",
+			data:     "x; rm -rf / && $(id)",
+			quoting:  commandQuoting,
+			expected: "echo 'x; rm -rf / && $(id)'",
+		},
+		{
+			name:     "빈 값",
+			tmpl:     "echo {{.}}",
+			data:     "",
+			quoting:  commandQuoting,
+			expected: "echo ''",
+		},
+		{
+			name:     "슬라이스는 각 요소를 인수로",
+			tmpl:     "go test {{.}}",
+			data:     []string{"./a", "b c"},
+			quoting:  commandQuoting,
+			expected: "go test ./a 'b c'",
+		},
+		{
+			name:     "raw 함수로 제외",
+			tmpl:     "ls {{raw .}}",
+			data:     "-la *.go",
+			quoting:  commandQuoting,
+			expected: "ls -la *.go",
+		},
+		{
+			name:     "raw 파이프라인",
+			tmpl:     "ls {{. | raw}}",
+			data:     "-la *.go",
+			quoting:  commandQuoting,
+			expected: "ls -la *.go",
+		},
+		{
+			name:     "조건문과 반복문 안의 값",
+			tmpl:     `{{if .Verbose}}-v {{end}}{{range .Files}}{{.}} {{end}}`,
+			data:     map[string]any{"Verbose": true, "Files": []string{"a b", "c"}},
+			quoting:  commandQuoting,
+			expected: "-v 'a b' c ",
+		},
+		{
+			name:     "변수 선언",
+			tmpl:     `{{$name := .}}echo {{$name}}`,
+			data:     "a b",
+			quoting:  commandQuoting,
+			expected: "echo 'a b'",
+		},
+		{
+			name:     "빈 값도 하나의 인수",
+			tmpl:     "printf [%s] {{.A}} {{.B}}",
+			data:     map[string]string{"A": "", "B": "x"},
+			quoting:  commandQuoting,
+			expected: "printf [%s] '' x",
+		},
+		{
+			name:     "큰따옴표 안의 값",
+			tmpl:     `echo "x {{.}} y"`,
+			data:     `a "b" $c`,
+			quoting:  commandQuoting,
+			expected: `echo "x "'a "b" $c'" y"`,
+		},
+		{
+			name:     "작은따옴표 안의 값",
+			tmpl:     `echo 'x {{.}}'`,
+			data:     "it's",
+			quoting:  commandQuoting,
+			expected: `echo 'x ''it'"'"'s'''`,
+		},
+		{
+			name:     "이스케이프된 인용부호 뒤의 값",
+			tmpl:     `echo \"{{.}}`,
+			data:     "a b",
+			quoting:  commandQuoting,
+			expected: `echo \"'a b'`,
+		},
+		{
+			name:     "인용부호 안의 슬라이스는 하나의 문자열",
+			tmpl:     `echo "{{.}}"`,
+			data:     []string{"a", "b c"},
+			quoting:  commandQuoting,
+			expected: `echo ""'a b c'""`,
+		},
+		{
+			name:     "쉘 큰따옴표 안의 이스케이프된 큰따옴표",
+			tmpl:     `echo "\" {{.}}"`,
+			data:     "a b",
+			quoting:  posixShellQuoting,
+			expected: `echo "\" "'a b'""`,
+		},
+		{
+			name:     "PowerShell 작은따옴표 안의 값",
+			tmpl:     "Write-Output 'x {{.}}'",
+			data:     "it’s",
+			quoting:  powershellQuoting,
+			expected: "Write-Output 'x it’’s'",
+		},
+		{
+			name:     "PowerShell 큰따옴표 안의 값",
+			tmpl:     `Write-Output "x {{.}}"`,
+			data:     "$(id) `\"",
+			quoting:  powershellQuoting,
+			expected: "Write-Output \"x `$(id) ```\"\"",
+		},
+		{
+			name:     "PowerShell 작은따옴표",
+			tmpl:     "Write-Output {{.}}",
+			data:     "it's",
+			quoting:  powershellQuoting,
+			expected: "Write-Output 'it''s'",
+		},
+		{
+			name:     "PowerShell 유니코드 작은따옴표",
+			tmpl:     "Write-Output {{.}}",
+			data:     "a\u2018b\u2019c\u201Ad\u201Be",
+			quoting:  powershellQuoting,
+			expected: "Write-Output 'a\u2018\u2018b\u2019\u2019c\u201A\u201Ad\u201B\u201Be'",
+		},
+		{
+			name:     "PowerShell 유니코드 작은따옴표로 인용부호 탈출 시도",
+			tmpl:     "Write-Output {{.}}",
+			data:     "\u2019; Remove-Item x; \u2019",
+			quoting:  powershellQuoting,
+			expected: "Write-Output '\u2019\u2019; Remove-Item x; \u2019\u2019'",
+		},
+		{
+			name:     "PowerShell 매개변수 형태의 값",
+			tmpl:     "Remove-Item {{.}}",
+			data:     "-Recurse",
+			quoting:  powershellQuoting,
+			expected: "Remove-Item '-Recurse'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := renderTemplate(tt.tmpl, tt.data, tt.quoting)
+			if err != nil {
+				t.Fatalf("renderTemplate() 에러: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("renderTemplate() = %q, 기대값: %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRenderTemplateError(t *testing.T) {
+	if _, err := renderTemplate("echo {{.", nil, commandQuoting); err == nil {
+		t.Error("잘못된 템플릿에 대해 에러 기대, 결과: nil")
+	}
+	if _, err := renderTemplate("echo {{.Missing.Field}}", map[string]any{}, commandQuoting); err == nil {
+		t.Error("실행 실패에 대해 에러 기대, 결과: nil")
+	}
+}
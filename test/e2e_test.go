@@ -2,15 +2,35 @@ package test
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io/fs"
 	"os"
+	"os/exec"
+	"os/user"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/seungyeop-lee/easycmd"
+	"github.com/seungyeop-lee/easycmd/easycmdtest"
 )
 
+func TestMain(m *testing.M) {
+	// 리소스 제한 등 명령어 실행 직전에 적용하는 설정을 위해 테스트 바이너리가 다시 실행될 수 있음
+	easycmd.HelperMain()
+	os.Exit(m.Run())
+}
+
 func TestSimple(t *testing.T) {
 	// given
 	out := &bytes.Buffer{}
@@ -705,3 +725,2163 @@ func TestWithEnvMultiple(t *testing.T) {
 		t.Errorf("expected 'value1 value2 value3', got '%s'", result)
 	}
 }
+
+func TestWithIdleTimeoutExceeded(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithIdleTimeout(500*time.Millisecond),
+	)
+
+	// when - 한 번 출력한 뒤 출력 없이 오래 대기하는 명령어
+	start := time.Now()
+	err := cmd.RunShell("echo start; sleep 5")
+	elapsed := time.Since(start)
+
+	// then
+	if !errors.Is(err, easycmd.IdleTimeoutError) {
+		t.Fatalf("expected IdleTimeoutError, got %v", err)
+	}
+	if elapsed > 3*time.Second {
+		t.Errorf("expected idle timeout to stop the command early, took %s", elapsed)
+	}
+	if !strings.Contains(out.String(), "start") {
+		t.Errorf("expected output before idle timeout, got %s", out.String())
+	}
+}
+
+func TestWithIdleTimeoutKeepsRunningWhileOutput(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithStdErr(errOut),
+		easycmd.WithIdleTimeout(700*time.Millisecond),
+	)
+
+	// when - 전체 실행 시간은 유휴 타임아웃보다 길지만 stdout/stderr로 번갈아 계속 출력
+	err := cmd.RunShell("for i in 1 2 3 4 5; do echo $i; sleep 0.2; echo $i >&2; sleep 0.2; done")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+}
+
+func TestWithIdleTimeoutSharedOutput(t *testing.T) {
+	// given - stdout과 stderr에 같은 Writer 사용
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithStdErr(out),
+		easycmd.WithIdleTimeout(time.Second),
+	)
+
+	// when
+	err := cmd.RunShell("for i in 1 2 3 4 5; do echo out$i; echo err$i >&2; done")
+
+	// then - 하나의 파이프로 전달되므로 출력 순서가 유지되어야 함
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	var expected strings.Builder
+	for i := 1; i <= 5; i++ {
+		fmt.Fprintf(&expected, "out%d\nerr%d\n", i, i)
+	}
+	if out.String() != expected.String() {
+		t.Errorf("expected %q, got %q", expected.String(), out.String())
+	}
+}
+
+func TestSharedOutputWithLineHandler(t *testing.T) {
+	// given - 같은 Writer에 stdout/stderr을 출력하면서 stdout 줄 핸들러도 사용
+	out := &bytes.Buffer{}
+	var lines []string
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithStdErr(out),
+		easycmd.WithStdOutLineHandler(func(line string) {
+			lines = append(lines, line)
+		}),
+	)
+
+	// when
+	err := cmd.RunShell("for i in $(seq 1 200); do echo out$i; echo err$i >&2; done")
+
+	// then - 두 출력이 동시에 써도 손실 없이 모두 기록되어야 함
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if got := strings.Count(out.String(), "\n"); got != 400 {
+		t.Errorf("expected 400 lines, got %d", got)
+	}
+	if len(lines) != 200 {
+		t.Errorf("expected 200 stdout lines, got %d", len(lines))
+	}
+}
+
+func TestDebugModeIdleTimeout(t *testing.T) {
+	// given
+	debugOut := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithDebug(debugOut),
+		easycmd.WithIdleTimeout(300*time.Millisecond),
+	)
+
+	// when
+	err := cmd.Run("sleep 3")
+
+	// then
+	if !errors.Is(err, easycmd.IdleTimeoutError) {
+		t.Fatalf("expected IdleTimeoutError, got %v", err)
+	}
+
+	debugResult := debugOut.String()
+	if !strings.Contains(debugResult, "[DEBUG] 유휴 타임아웃 설정: 300ms") {
+		t.Errorf("expected debug output to contain idle timeout setting, got %s", debugResult)
+	}
+	if !strings.Contains(debugResult, "[DEBUG] 명령어 유휴 타임아웃: 300ms 동안 출력 없음") {
+		t.Errorf("expected debug output to contain idle timeout event, got %s", debugResult)
+	}
+}
+
+func TestStdOutLineHandler(t *testing.T) {
+	// given
+	var lines []string
+	cmd := easycmd.New(
+		easycmd.WithStdOutLineHandler(func(line string) {
+			lines = append(lines, line)
+		}),
+	)
+
+	// when - 마지막 줄은 줄바꿈 없이 끝나는 명령어
+	err := cmd.RunShell("printf 'first\\r\\nsecond\\nlast'")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+
+	expected := []string{"first", "second", "last"}
+	if strings.Join(lines, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %q, got %q", expected, lines)
+	}
+}
+
+func TestStdErrLineHandlerWithStdOut(t *testing.T) {
+	// given - 줄 단위 handler와 Writer를 함께 설정
+	out := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+	var errLines []string
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithStdErr(errOut),
+		easycmd.WithStdErrLineHandler(func(line string) {
+			errLines = append(errLines, line)
+		}),
+	)
+
+	// when
+	err := cmd.RunShell("echo out; echo err1 >&2; echo err2 >&2")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "out\n" {
+		t.Errorf("expected 'out', got %s", out.String())
+	}
+	if errOut.String() != "err1\nerr2\n" {
+		t.Errorf("expected stderr writer to receive output too, got %s", errOut.String())
+	}
+	if strings.Join(errLines, ",") != "err1,err2" {
+		t.Errorf("expected [err1 err2], got %q", errLines)
+	}
+}
+
+func TestStdOutLineChan(t *testing.T) {
+	// given
+	ch := make(chan string, 10)
+	cmd := easycmd.New(
+		easycmd.WithStdOutLineChan(ch),
+		easycmd.WithMaxLineLength(4),
+	)
+
+	// when - 최대 길이를 넘는 줄 출력
+	err := cmd.Run("echo abcdefghij")
+	close(ch)
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+
+	var lines []string
+	for line := range ch {
+		lines = append(lines, line)
+	}
+	if strings.Join(lines, ",") != "abcd,efgh,ij" {
+		t.Errorf("expected [abcd efgh ij], got %q", lines)
+	}
+}
+
+func TestWithOutputPrefix(t *testing.T) {
+	// given - 터미널이 아닌 Writer이므로 색상은 사용되지 않음
+	out := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithStdErr(errOut),
+		easycmd.WithOutputPrefix("api", easycmd.ColorGreen),
+	)
+
+	// when
+	err := cmd.RunShell("echo one; echo two; echo oops >&2; printf last")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "api | one\napi | two\napi | last\n" {
+		t.Errorf("expected prefixed stdout, got %q", out.String())
+	}
+	if errOut.String() != "api | oops\n" {
+		t.Errorf("expected prefixed stderr, got %q", errOut.String())
+	}
+}
+
+func TestWithOutputPrefixParallel(t *testing.T) {
+	// given - 같은 Writer를 공유하는 두 Cmd
+	out := &bytes.Buffer{}
+	line := strings.Repeat("x", 2000)
+	script := "for i in $(seq 1 100); do echo " + line + "; done"
+
+	var wg sync.WaitGroup
+	for _, label := range []string{"web", "worker"} {
+		wg.Add(1)
+		go func(label string) {
+			defer wg.Done()
+			cmd := easycmd.New(
+				easycmd.WithStdOut(out),
+				easycmd.WithOutputPrefix(label, easycmd.ColorNone),
+			)
+			if err := cmd.RunShell(script); err != nil {
+				t.Errorf("expected nil, got %v", err)
+			}
+		}(label)
+	}
+	wg.Wait()
+
+	// then - 모든 줄이 온전한 형태여야 함
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 200 {
+		t.Fatalf("expected 200 lines, got %d", len(lines))
+	}
+	for _, l := range lines {
+		if l != "web | "+line && l != "worker | "+line {
+			t.Fatalf("expected intact prefixed line, got %q", l[:min(len(l), 40)])
+		}
+	}
+}
+
+func TestRunParallelCollectAll(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out))
+	specs := []easycmd.Spec{
+		{Command: "for i in 1 2 3; do echo a$i; sleep 0.1; done", Shell: true},
+		{Command: "false"},
+		{Command: "for i in 1 2 3; do echo b$i; sleep 0.1; done", Shell: true},
+	}
+
+	// when
+	err := cmd.RunParallel(context.Background(), specs, easycmd.ParallelOptions{})
+
+	// then - 실패한 명령어만 에러로 집계
+	var parallelErr *easycmd.ParallelError
+	if !errors.As(err, &parallelErr) {
+		t.Fatalf("expected ParallelError, got %v", err)
+	}
+	if len(parallelErr.Errors) != 1 || parallelErr.Errors[0].Spec.Command != "false" {
+		t.Errorf("expected only 'false' to fail, got %v", parallelErr)
+	}
+
+	// 명령어별 출력이 섞이지 않고 묶여서 출력
+	result := out.String()
+	if !strings.Contains(result, "a1\na2\na3\n") || !strings.Contains(result, "b1\nb2\nb3\n") {
+		t.Errorf("expected grouped output, got %q", result)
+	}
+}
+
+func TestRunParallelGroupedLinesAndDebug(t *testing.T) {
+	// given - 줄 단위 handler와 디버그 출력을 사용하는 병렬 실행
+	var lines []string
+	debugOut := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOutLineHandler(func(line string) { lines = append(lines, line) }),
+		easycmd.WithDebug(debugOut),
+	)
+	specs := []easycmd.Spec{
+		{Command: "for i in 1 2 3; do echo a$i; sleep 0.1; done", Shell: true},
+		{Command: "for i in 1 2 3; do echo b$i; sleep 0.1; done", Shell: true},
+	}
+
+	// when
+	err := cmd.RunParallel(context.Background(), specs, easycmd.ParallelOptions{})
+
+	// then - handler 호출과 디버그 출력이 명령어 단위로 묶여야 함
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	result := strings.Join(lines, " ")
+	if result != "a1 a2 a3 b1 b2 b3" && result != "b1 b2 b3 a1 a2 a3" {
+		t.Errorf("expected grouped line handler calls, got %q", result)
+	}
+	debug := debugOut.String()
+	first := strings.Index(debug, "명령어 실행 완료")
+	if first < 0 || strings.Count(debug[:first], "명령어 실행 시작") != 1 {
+		t.Errorf("expected grouped debug output, got %s", debug)
+	}
+}
+
+func TestRunParallelFailFast(t *testing.T) {
+	// given
+	cmd := easycmd.New()
+	specs := []easycmd.Spec{
+		{Command: "sleep 5"},
+		{Command: "sleep 0.2 && false", Shell: true},
+		{Command: "sleep 5"},
+	}
+
+	// when
+	start := time.Now()
+	err := cmd.RunParallel(context.Background(), specs, easycmd.ParallelOptions{FailFast: true})
+	elapsed := time.Since(start)
+
+	// then - 첫 실패 후 나머지 명령어는 종료되고 에러로 집계되지 않음
+	var parallelErr *easycmd.ParallelError
+	if !errors.As(err, &parallelErr) {
+		t.Fatalf("expected ParallelError, got %v", err)
+	}
+	if len(parallelErr.Errors) != 1 {
+		t.Errorf("expected 1 error, got %v", parallelErr)
+	}
+	if elapsed > 3*time.Second {
+		t.Errorf("expected siblings to be cancelled, took %s", elapsed)
+	}
+}
+
+func TestRunParallelMaxConcurrency(t *testing.T) {
+	// given
+	cmd := easycmd.New()
+	specs := []easycmd.Spec{
+		{Command: "sleep 0.3"},
+		{Command: "sleep 0.3"},
+		{Command: "sleep 0.3"},
+		{Command: "sleep 0.3"},
+	}
+
+	// when
+	start := time.Now()
+	err := cmd.RunParallel(context.Background(), specs, easycmd.ParallelOptions{MaxConcurrency: 2})
+	elapsed := time.Since(start)
+
+	// then - 2개씩 두 번 실행되어야 함
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if elapsed < 600*time.Millisecond {
+		t.Errorf("expected concurrency limit to be applied, took %s", elapsed)
+	}
+}
+
+func TestGroupContextCancel(t *testing.T) {
+	// given
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	g := easycmd.New().NewGroup(ctx, easycmd.ParallelOptions{})
+
+	// when
+	g.Go(easycmd.Spec{Command: "sleep 5"})
+	err := g.Wait()
+
+	// then
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context deadline exceeded, got %v", err)
+	}
+}
+
+func TestRunPipeline(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out))
+
+	// when - bash 없이 세 단계 파이프라인 실행
+	result, err := cmd.RunPipeline(`printf 'a\nfix1\nb\nfix2\n'`, "grep fix", "wc -l")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if strings.TrimSpace(out.String()) != "2" {
+		t.Errorf("expected '2', got '%s'", out.String())
+	}
+	if fmt.Sprint(result.ExitCodes) != "[0 0 0]" {
+		t.Errorf("expected [0 0 0], got %v", result.ExitCodes)
+	}
+}
+
+func TestRunPipelineWithStdIn(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdIn(strings.NewReader("b\na\nb\nc\n")),
+		easycmd.WithStdOut(out),
+	)
+
+	// when
+	_, err := cmd.RunPipeline("sort", "uniq")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "a\nb\nc\n" {
+		t.Errorf("expected 'a b c', got %q", out.String())
+	}
+}
+
+func TestRunPipelineFailedStage(t *testing.T) {
+	// given
+	cmd := easycmd.New(easycmd.WithStdOut(&bytes.Buffer{}))
+
+	// when - 중간 단계가 실패하는 파이프라인
+	result, err := cmd.RunPipeline("true", "false", "cat")
+
+	// then - pipefail과 같이 실패한 단계가 보고되어야 함
+	var pipelineErr *easycmd.PipelineError
+	if !errors.As(err, &pipelineErr) {
+		t.Fatalf("expected PipelineError, got %v", err)
+	}
+	if pipelineErr.Stage != 1 || pipelineErr.Command != "false" {
+		t.Errorf("expected stage 1 'false', got %d %s", pipelineErr.Stage, pipelineErr.Command)
+	}
+	if fmt.Sprint(result.ExitCodes) != "[0 1 0]" {
+		t.Errorf("expected [0 1 0], got %v", result.ExitCodes)
+	}
+}
+
+func TestRunPipelineTimeout(t *testing.T) {
+	// given
+	cmd := easycmd.New(easycmd.WithTimeoutMillis(300))
+
+	// when - 모든 단계에 타임아웃이 적용되어야 함
+	start := time.Now()
+	_, err := cmd.RunPipeline("sleep 5", "sleep 5")
+	elapsed := time.Since(start)
+
+	// then
+	if err == nil || !strings.Contains(err.Error(), "명령어 실행 타임아웃") {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+	if elapsed > 3*time.Second {
+		t.Errorf("expected all stages to be stopped, took %s", elapsed)
+	}
+}
+
+func TestRunPipelineIdleTimeoutIntermediateOutput(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out), easycmd.WithIdleTimeout(300*time.Millisecond))
+
+	// when - 마지막 단계는 끝날 때만 출력하지만 앞 단계는 계속 출력
+	_, err := cmd.RunPipeline("sh -c 'for i in 1 2 3 4 5 6 7 8; do echo $i; sleep 0.1; done'", "sort -r")
+
+	// then
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if out.String() != "8\n7\n6\n5\n4\n3\n2\n1\n" {
+		t.Errorf("unexpected output: %q", out.String())
+	}
+}
+
+func TestRunPipelineSharedOutput(t *testing.T) {
+	// given - stdout과 stderr에 같은 Writer 사용
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out), easycmd.WithStdErr(out), easycmd.WithIdleTimeout(time.Second))
+
+	// when - 앞 단계의 stderr과 마지막 단계의 stdout이 동시에 출력
+	_, err := cmd.RunPipeline(
+		"sh -c 'for i in $(seq 1 200); do echo out$i; echo err$i >&2; done'",
+		"cat",
+	)
+
+	// then
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if got := strings.Count(out.String(), "\n"); got != 400 {
+		t.Errorf("expected 400 lines, got %d", got)
+	}
+}
+
+func TestRunPipelineEmpty(t *testing.T) {
+	cmd := easycmd.New()
+
+	_, err := cmd.RunPipeline("echo hello", "")
+
+	if !errors.Is(err, easycmd.EmptyCmdError) {
+		t.Errorf("expected EmptyCmdError, got %v", err)
+	}
+}
+
+func TestRunPipelineStageExpansion(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "in.txt"), []byte("b\na\nb\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithEnv([]string{"PATTERN=b", "DIR=" + dir}),
+		easycmd.WithExpandEnv(),
+	)
+
+	// when - 각 단계에 Run과 같은 확장, 환경변수 지정, 리다이렉션 적용
+	_, err := cmd.RunPipeline("grep $PATTERN < $DIR/in.txt", "LC_ALL=C sort", "uniq -c > $DIR/out.txt 2>&1")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected empty stdout, got %q", out.String())
+	}
+	written, err := os.ReadFile(filepath.Join(dir, "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(string(written)) != "2 b" {
+		t.Errorf("expected '2 b', got %q", string(written))
+	}
+}
+
+func TestRunPipelineStageListOperator(t *testing.T) {
+	cmd := easycmd.New()
+
+	_, err := cmd.RunPipeline("echo a && echo b", "cat")
+
+	if err == nil {
+		t.Error("expected error for list operator in pipeline stage, got nil")
+	}
+}
+
+func TestRunQuotedEmptyArgument(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out), easycmd.WithExpandEnv())
+
+	// when - 인용부호로 감싼 빈 값은 빈 인수로 전달되어야 함
+	err := cmd.Run(`printf [%s] "$EASYCMD_UNSET" x $EASYCMD_UNSET`)
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "[][x]" {
+		t.Errorf("expected '[][x]', got %q", out.String())
+	}
+}
+
+func TestRunWithRedirection(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "in.txt"), []byte("b\nc\na\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out))
+
+	// when - 실행 디렉토리 기준 상대 경로로 리다이렉션
+	err := cmd.RunWithDir("sort < in.txt > out.txt", dir)
+
+	// then - stdout 설정 대신 파일로 출력되어야 함
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "" {
+		t.Errorf("expected empty stdout, got %s", out.String())
+	}
+	result, _ := os.ReadFile(filepath.Join(dir, "out.txt"))
+	if string(result) != "a\nb\nc\n" {
+		t.Errorf("expected sorted file, got %q", string(result))
+	}
+}
+
+func TestRunWithAppendRedirection(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "log.txt")
+	cmd := easycmd.New()
+
+	// when
+	err1 := cmd.Run("echo first > " + path)
+	err2 := cmd.Run("echo second >> " + path)
+
+	// then
+	if err1 != nil || err2 != nil {
+		t.Errorf("expected nil, got %v, %v", err1, err2)
+	}
+	result, _ := os.ReadFile(path)
+	if string(result) != "first\nsecond\n" {
+		t.Errorf("expected appended file, got %q", string(result))
+	}
+}
+
+func TestRunWithStdErrRedirection(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithStdErr(errOut),
+	)
+
+	// when - stderr을 stdout으로 복제
+	err := cmd.Run("ls /nonexistent/path12345 2>&1")
+
+	// then
+	if err == nil {
+		t.Error("expected error from ls, got nil")
+	}
+	if errOut.String() != "" {
+		t.Errorf("expected empty stderr, got %s", errOut.String())
+	}
+	if !strings.Contains(out.String(), "nonexistent") {
+		t.Errorf("expected stderr in stdout, got %s", out.String())
+	}
+}
+
+func TestWithLiteralArgs(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithLiteralArgs(),
+	)
+
+	// when - 리다이렉션 연산자가 그대로 인수로 전달되어야 함
+	err := cmd.Run("echo a > b")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "a > b\n" {
+		t.Errorf("expected 'a > b', got %q", out.String())
+	}
+}
+
+func TestRunCommandListAnd(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out))
+
+	// when - bash 없이 && 연결 실행
+	err := cmd.Run("echo build && echo test")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "build\ntest\n" {
+		t.Errorf("expected 'build test', got %q", out.String())
+	}
+}
+
+func TestRunCommandListShortCircuit(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  string
+		expected string
+		wantErr  bool
+	}{
+		{name: "&& 실패 시 중단", command: "false && echo skipped", expected: "", wantErr: true},
+		{name: "|| 실패 시 실행", command: "false || echo fallback", expected: "fallback\n"},
+		{name: "|| 성공 시 건너뜀", command: "echo ok || echo skipped", expected: "ok\n"},
+		{name: "; 항상 실행", command: "false ; echo always", expected: "always\n"},
+		{name: "건너뛴 명령어 이후", command: "false && echo a || echo b", expected: "b\n"},
+		{name: "마지막 실패 반환", command: "echo a ; false", expected: "a\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := easycmd.New(easycmd.WithStdOut(out))
+
+			err := cmd.Run(tt.command)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, out.String())
+			}
+		})
+	}
+}
+
+func TestRunCommandListSharesDir(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out))
+
+	// when - 모든 명령어가 같은 실행 디렉토리를 사용
+	err := cmd.RunWithDir("touch a.txt && ls", dir)
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if strings.TrimSpace(out.String()) != "a.txt" {
+		t.Errorf("expected 'a.txt', got %q", out.String())
+	}
+}
+
+func TestDebugModeCommandList(t *testing.T) {
+	// given
+	debugOut := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(&bytes.Buffer{}),
+		easycmd.WithDebug(debugOut),
+	)
+
+	// when
+	err := cmd.Run("echo a && echo 'b c'")
+
+	// then - 각 명령어가 따로 로깅되어야 함
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	debugResult := debugOut.String()
+	if !strings.Contains(debugResult, "[DEBUG] 파싱된 명령어: echo a\n") {
+		t.Errorf("expected first sub-command in debug output, got %s", debugResult)
+	}
+	if !strings.Contains(debugResult, "[DEBUG] 파싱된 명령어: echo 'b c'\n") {
+		t.Errorf("expected second sub-command in debug output, got %s", debugResult)
+	}
+	if strings.Count(debugResult, "[DEBUG] 명령어 실행 완료") != 2 {
+		t.Errorf("expected two completion messages, got %s", debugResult)
+	}
+}
+
+func TestRunCommandListTimeout(t *testing.T) {
+	// given - 각 명령어는 타임아웃보다 짧지만 전체는 김
+	cmd := easycmd.New(easycmd.WithTimeout(700 * time.Millisecond))
+
+	// when
+	start := time.Now()
+	err := cmd.Run("sleep 0.5 && sleep 0.5 && sleep 0.5")
+	elapsed := time.Since(start)
+
+	// then - 타임아웃은 목록 전체에 적용되어야 함
+	if err == nil || !strings.Contains(err.Error(), "타임아웃") {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+	if elapsed > 1200*time.Millisecond {
+		t.Errorf("expected the list to stop at the timeout, took %s", elapsed)
+	}
+}
+
+func TestRunCommandListCancelledAfterSuccess(t *testing.T) {
+	// given - 첫 명령어가 성공한 직후 context가 취소됨
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fake := easycmdtest.NewFake()
+	fake.On("echo", "first").Handle(func(req easycmd.Request) int {
+		cancel()
+		return 0
+	})
+	fake.On("echo", "second")
+	cmd := easycmd.New(easycmd.WithExecutor(fake))
+
+	// when
+	g := cmd.NewGroup(ctx, easycmd.ParallelOptions{})
+	g.Go(easycmd.Spec{Command: "echo first; echo second"})
+	err := g.Wait()
+
+	// then - 나머지 명령어는 실행하지 않고 취소 에러를 반환해야 함
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context canceled, got %v", err)
+	}
+	fake.AssertCalls(t, "echo first")
+}
+
+func TestRunWithEnvAssignment(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out))
+
+	// when - 명령어 앞에 환경변수 지정
+	err := cmd.Run("GREETING='hello world' printenv GREETING")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "hello world\n" {
+		t.Errorf("expected 'hello world', got %q", out.String())
+	}
+}
+
+func TestRunWithEnvAssignmentMergedWithEnv(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	debugOut := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithDebug(debugOut),
+		easycmd.WithEnv([]string{"VAR1=value1", "VAR2=value2"}),
+	)
+
+	// when - WithEnv 설정 중 일부를 덮어씀
+	err := cmd.Run("VAR2=override env")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "VAR1=value1\nVAR2=override\n" {
+		t.Errorf("expected merged env, got %q", out.String())
+	}
+	if !strings.Contains(debugOut.String(), "[DEBUG] 명령어 환경변수 지정: [VAR2=override]") {
+		t.Errorf("expected debug output to contain env assignment, got %s", debugOut.String())
+	}
+	if !strings.Contains(debugOut.String(), "[DEBUG] 실행 명령어: env\n") {
+		t.Errorf("expected command name to be env, got %s", debugOut.String())
+	}
+}
+
+func TestWithExpandEnv(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithEnv([]string{"NAME=easycmd"}),
+		easycmd.WithExpandEnv(),
+	)
+
+	// when - WithEnv로 설정한 환경변수로 확장되어야 함
+	err := cmd.Run(`echo $NAME "${VERSION:-dev}" '$NAME'`)
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "easycmd dev $NAME\n" {
+		t.Errorf("expected 'easycmd dev $NAME', got %q", out.String())
+	}
+}
+
+func TestWithExpandEnvRequired(t *testing.T) {
+	// given
+	cmd := easycmd.New(easycmd.WithExpandEnv())
+
+	// when - 필수 환경변수가 없는 경우
+	err := cmd.Run(`echo "${EASYCMD_UNSET_VAR:?must be set}"`)
+
+	// then
+	if err == nil || !strings.Contains(err.Error(), "must be set") {
+		t.Errorf("expected required variable error, got %v", err)
+	}
+}
+
+func TestWithoutExpandEnv(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out))
+
+	// when - 기본적으로는 확장하지 않음
+	err := cmd.Run("echo $HOME")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "$HOME\n" {
+		t.Errorf("expected '$HOME', got %q", out.String())
+	}
+}
+
+func TestWithGlob(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	for _, f := range []string{"a.o", "b.o", "main.c"} {
+		os.WriteFile(filepath.Join(dir, f), nil, 0o644)
+	}
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithGlob(easycmd.GlobKeep),
+	)
+
+	// when - 실행 디렉토리 기준으로 glob 확장
+	err := cmd.RunWithDir(`echo *.o '*.o' *.rs`, dir)
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "a.o b.o *.o *.rs\n" {
+		t.Errorf("expected 'a.o b.o *.o *.rs', got %q", out.String())
+	}
+}
+
+func TestWithGlobFail(t *testing.T) {
+	// given
+	cmd := easycmd.New(easycmd.WithGlob(easycmd.GlobFail))
+
+	// when - 일치하는 파일이 없는 패턴
+	err := cmd.RunWithDir("rm -f *.o", t.TempDir())
+
+	// then
+	if err == nil || !strings.Contains(err.Error(), "일치하는 파일이 없습니다") {
+		t.Errorf("expected no match error, got %v", err)
+	}
+}
+
+func TestWithExpandTilde(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithEnv([]string{"HOME=/home/tester"}),
+		easycmd.WithExpandTilde(),
+	)
+
+	// when - 명령어 환경변수의 HOME 기준으로 확장
+	err := cmd.Run(`echo ~/projects "~/quoted"`)
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "/home/tester/projects ~/quoted\n" {
+		t.Errorf("expected expanded home, got %q", out.String())
+	}
+}
+
+func TestRunTemplate(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out))
+
+	// when - 인용부호와 연산자가 포함된 값도 하나의 인수로 전달되어야 함
+	err := cmd.RunTemplate("printf %s\\n {{.Message}} {{.Name}}", map[string]string{
+		"Message": "it's; echo injected",
+		"Name":    "a > b",
+	})
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "it's; echo injected\na > b\n" {
+		t.Errorf("expected values as single args, got %q", out.String())
+	}
+}
+
+func TestRunTemplateEmptyValue(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out))
+
+	// when - 빈 값도 하나의 인수로 전달되어야 함
+	err := cmd.RunTemplate("printf [%s] {{.A}} {{.B}}", map[string]string{"A": "", "B": "x"})
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "[][x]" {
+		t.Errorf("expected '[][x]', got %q", out.String())
+	}
+}
+
+func TestRunTemplateInQuotes(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out))
+
+	// when - 인용부호 안에 삽입한 값은 인용부호 안 문자열의 일부가 되어야 함
+	err := cmd.RunTemplate(`printf %s\n "{{.A}}" 'x {{.B}}'`, map[string]string{"A": "a b", "B": `it's "q"`})
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "a b\nx it's \"q\"\n" {
+		t.Errorf("expected values without extra quotes, got %q", out.String())
+	}
+}
+
+func TestRunShellTemplate(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out))
+
+	// when
+	err := cmd.RunShellTemplate("echo {{.}} | tr a-z A-Z", "$(echo injected) 'quoted'")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "$(ECHO INJECTED) 'QUOTED'\n" {
+		t.Errorf("expected literal value, got %q", out.String())
+	}
+}
+
+func TestRunShellTemplateInQuotes(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out))
+
+	// when
+	err := cmd.RunShellTemplate(`echo "value: {{.}}"`, "$(echo injected) \"a  b\"")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "value: $(echo injected) \"a  b\"\n" {
+		t.Errorf("expected literal value, got %q", out.String())
+	}
+}
+
+func TestWithShellPreset(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	debugOut := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithDebug(debugOut),
+		easycmd.WithShellPreset(easycmd.ShellSh),
+	)
+
+	// when
+	err := cmd.RunShell("echo from sh")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "from sh\n" {
+		t.Errorf("expected 'from sh', got %q", out.String())
+	}
+	if !strings.Contains(debugOut.String(), "[DEBUG] 파싱된 명령어: sh -c echo from sh") {
+		t.Errorf("expected sh in debug output, got %s", debugOut.String())
+	}
+}
+
+func TestWithShellAndFlags(t *testing.T) {
+	// given - -e 옵션으로 실패한 줄에서 중단
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithShell("/bin/sh"),
+		easycmd.WithShellFlags("-e"),
+	)
+
+	// when
+	err := cmd.RunShell("echo before; false; echo after")
+
+	// then
+	if err == nil {
+		t.Error("expected error, got nil")
+	}
+	if out.String() != "before\n" {
+		t.Errorf("expected only 'before', got %q", out.String())
+	}
+}
+
+func TestRunShellFallbackWithoutBash(t *testing.T) {
+	// given - PATH에 sh만 있는 환경
+	shPath, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not installed, skipping test")
+	}
+	binDir := t.TempDir()
+	if err := os.Symlink(shPath, filepath.Join(binDir, "sh")); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir)
+
+	debugOut := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(&bytes.Buffer{}),
+		easycmd.WithDebug(debugOut),
+	)
+
+	// when
+	err = cmd.RunShell("echo fallback")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if !strings.Contains(debugOut.String(), "[DEBUG] 실행 명령어: sh") {
+		t.Errorf("expected sh fallback, got %s", debugOut.String())
+	}
+}
+
+func TestWithShellStrict(t *testing.T) {
+	// given
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not installed, skipping test")
+	}
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithShellPreset(easycmd.ShellBash),
+		easycmd.WithShellStrict(),
+	)
+
+	// when
+	err := cmd.RunShell("echo first\nfalse | cat\necho unreachable")
+
+	// then
+	var scriptErr *easycmd.ScriptError
+	if !errors.As(err, &scriptErr) {
+		t.Fatalf("expected ScriptError, got %v", err)
+	}
+	if scriptErr.Line != 2 {
+		t.Errorf("expected line 2, got %d", scriptErr.Line)
+	}
+	if out.String() != "first\n" {
+		t.Errorf("expected only 'first', got %q", out.String())
+	}
+}
+
+func TestWithShellStrictFunction(t *testing.T) {
+	// given
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not installed, skipping test")
+	}
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithShellPreset(easycmd.ShellBash),
+		easycmd.WithShellStrict(),
+	)
+
+	// when - 함수 안에서 실패
+	err := cmd.RunShell("f() { false; }\necho first\nf\necho unreachable")
+
+	// then - 함수 안의 실패한 줄을 알려야 함
+	var scriptErr *easycmd.ScriptError
+	if !errors.As(err, &scriptErr) {
+		t.Fatalf("expected ScriptError, got %v", err)
+	}
+	if scriptErr.Line != 1 {
+		t.Errorf("expected line 1, got %d", scriptErr.Line)
+	}
+	if out.String() != "first\n" {
+		t.Errorf("expected only 'first', got %q", out.String())
+	}
+}
+
+func TestWithShellStrictPosixShell(t *testing.T) {
+	// given - sh는 줄 번호 없이 엄격 모드만 적용
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithShellPreset(easycmd.ShellSh),
+		easycmd.WithShellStrict(),
+	)
+
+	// when
+	err := cmd.RunShell("echo first\nfalse\necho unreachable")
+
+	// then
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	var scriptErr *easycmd.ScriptError
+	if errors.As(err, &scriptErr) {
+		t.Errorf("expected plain error, got ScriptError line %d", scriptErr.Line)
+	}
+	if out.String() != "first\n" {
+		t.Errorf("expected only 'first', got %q", out.String())
+	}
+}
+
+func TestWithShellStrictSuccess(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithShellStrict(),
+	)
+
+	// when
+	err := cmd.RunShell("echo ok || true")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "ok\n" {
+		t.Errorf("expected 'ok', got %q", out.String())
+	}
+}
+
+func TestRunScript(t *testing.T) {
+	// given
+	scripts := fstest.MapFS{
+		"scripts/greet.sh": {Data: []byte("#!/bin/sh\necho \"hello $1 $2\"\necho \"$0\" >&2\n")},
+	}
+	out := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out), easycmd.WithStdErr(errOut))
+
+	// when
+	err := cmd.RunScript(scripts, "scripts/greet.sh", "big world", "!")
+
+	// then
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if out.String() != "hello big world !\n" {
+		t.Errorf("expected 'hello big world !', got %q", out.String())
+	}
+	scriptPath := strings.TrimSpace(errOut.String())
+	if filepath.Base(scriptPath) != "greet.sh" {
+		t.Errorf("expected script name greet.sh, got %q", scriptPath)
+	}
+	if _, err := os.Stat(filepath.Dir(scriptPath)); !os.IsNotExist(err) {
+		t.Errorf("expected temp dir to be removed, got %v", err)
+	}
+}
+
+func TestRunScriptWithoutShebang(t *testing.T) {
+	// given - shebang이 없으면 RunShell과 같은 쉘 사용
+	scripts := fstest.MapFS{
+		"run.sh": {Data: []byte("echo $#\n")},
+	}
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out), easycmd.WithShellPreset(easycmd.ShellSh))
+
+	// when
+	err := cmd.RunScript(scripts, "run.sh", "a", "b", "c")
+
+	// then
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if out.String() != "3\n" {
+		t.Errorf("expected '3', got %q", out.String())
+	}
+}
+
+func TestRunScriptNotFound(t *testing.T) {
+	// given
+	cmd := easycmd.New()
+
+	// when
+	err := cmd.RunScript(fstest.MapFS{}, "missing.sh")
+
+	// then
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected fs.ErrNotExist, got %v", err)
+	}
+}
+
+func TestRunShellArgs(t *testing.T) {
+	// given - 인수 값이 스크립트로 해석되지 않아야 함
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out))
+
+	// when
+	err := cmd.RunShellArgs(`printf '%s|' "$#" "$1" "$2"`, "a b; echo injected", "$(whoami)")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "2|a b; echo injected|$(whoami)|" {
+		t.Errorf("unexpected output: %q", out.String())
+	}
+}
+
+func TestRunShellArgsWithStrict(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithShellPreset(easycmd.ShellSh),
+		easycmd.WithShellStrict(),
+	)
+
+	// when
+	err := cmd.RunShellArgs(`echo "$0 $1"`, "arg")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "sh arg\n" {
+		t.Errorf("expected 'sh arg', got %q", out.String())
+	}
+}
+
+func TestRunShellArgsEmpty(t *testing.T) {
+	// given
+	cmd := easycmd.New()
+
+	// when
+	err := cmd.RunShellArgs("", "a")
+
+	// then
+	if !errors.Is(err, easycmd.EmptyCmdError) {
+		t.Errorf("expected EmptyCmdError, got %v", err)
+	}
+}
+
+func TestRunPipelineEarlyExit(t *testing.T) {
+	// given - 다음 단계가 먼저 끝나면 앞 단계도 종료되어야 함
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out), easycmd.WithTimeoutSeconds(5))
+
+	// when
+	_, err := cmd.RunPipeline("yes", "head -n 1")
+
+	// then
+	if err != nil && strings.Contains(err.Error(), "타임아웃") {
+		t.Fatalf("expected pipeline to finish, got %v", err)
+	}
+	if out.String() != "y\n" {
+		t.Errorf("expected 'y', got %q", out.String())
+	}
+}
+
+func TestWithDryRun(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	dryOut := &bytes.Buffer{}
+	debugOut := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithDryRun(dryOut),
+		easycmd.WithDebug(debugOut),
+	)
+
+	// when
+	err := cmd.RunWithDir("touch created && echo 'hello world' > out.txt", dir)
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	expected := fmt.Sprintf("cd %s && touch created\ncd %s && echo 'hello world' > out.txt\n", dir, dir)
+	if dryOut.String() != expected {
+		t.Errorf("expected %q, got %q", expected, dryOut.String())
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("expected no files to be created, got %d", len(entries))
+	}
+	if strings.Count(debugOut.String(), "[DEBUG] 명령어 실행 완료") != 2 {
+		t.Errorf("expected 2 completion logs, got %s", debugOut.String())
+	}
+}
+
+func TestWithDryRunShellAndPipeline(t *testing.T) {
+	// given
+	dryOut := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithDryRun(dryOut),
+		easycmd.WithShellPreset(easycmd.ShellSh),
+	)
+
+	// when
+	shellErr := cmd.RunShell("rm -rf build; echo $HOME")
+	result, pipelineErr := cmd.RunPipeline("git log", "grep 'fix bug'")
+
+	// then
+	if shellErr != nil || pipelineErr != nil {
+		t.Errorf("expected nil, got %v, %v", shellErr, pipelineErr)
+	}
+	expected := "sh -c 'rm -rf build; echo $HOME'\ngit log | grep 'fix bug'\n"
+	if dryOut.String() != expected {
+		t.Errorf("expected %q, got %q", expected, dryOut.String())
+	}
+	if result.ExitCodes[0] != 0 || result.ExitCodes[1] != 0 {
+		t.Errorf("expected exit codes [0 0], got %v", result.ExitCodes)
+	}
+}
+
+func TestWithDryRunEnv(t *testing.T) {
+	// given
+	dryOut := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithDryRun(dryOut),
+		easycmd.WithEnv([]string{"PATH=/usr/bin:/bin", "MSG=a b"}),
+	)
+
+	// when
+	runErr := cmd.Run("GOOS=linux go build")
+	_, pipelineErr := cmd.RunPipeline("git log", "grep fix")
+
+	// then - WithEnv는 환경변수 전체를 대신하므로 env -i로 출력해야 함
+	if runErr != nil || pipelineErr != nil {
+		t.Errorf("expected nil, got %v, %v", runErr, pipelineErr)
+	}
+	expected := "env -i PATH=/usr/bin:/bin MSG='a b' GOOS=linux go build\n" +
+		"env -i PATH=/usr/bin:/bin MSG='a b' git log | env -i PATH=/usr/bin:/bin MSG='a b' grep fix\n"
+	if dryOut.String() != expected {
+		t.Errorf("expected %q, got %q", expected, dryOut.String())
+	}
+}
+
+func TestCheck(t *testing.T) {
+	// given - 명령어에 적용될 PATH에만 있는 실행 파일
+	binDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binDir, "deploy-tool"), []byte("#!/bin/sh\necho deployed\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cmd := easycmd.New(easycmd.WithEnv([]string{"PATH=" + binDir + ":/usr/bin:/bin"}))
+
+	// when
+	okErr := cmd.Check("deploy-tool --prod && echo done")
+	typoErr := cmd.Check("deploy-tol --prod")
+
+	// then
+	if okErr != nil {
+		t.Errorf("expected nil, got %v", okErr)
+	}
+	var notFound *easycmd.ExecutableNotFoundError
+	if !errors.As(typoErr, &notFound) {
+		t.Fatalf("expected ExecutableNotFoundError, got %v", typoErr)
+	}
+	if len(notFound.Suggestions) == 0 || notFound.Suggestions[0] != "deploy-tool" {
+		t.Errorf("expected suggestion deploy-tool, got %v", notFound.Suggestions)
+	}
+}
+
+func TestWithPreflight(t *testing.T) {
+	// given
+	binDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binDir, "deploy-tool"), []byte("#!/bin/sh\necho deployed\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithPreflight(),
+	)
+
+	// when - 명령어 앞에 지정한 PATH로 실행 파일을 찾음
+	err := cmd.Run("PATH=" + binDir + " deploy-tool")
+
+	// then
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if out.String() != "deployed\n" {
+		t.Errorf("expected 'deployed', got %q", out.String())
+	}
+}
+
+func TestWithPreflightRunDir(t *testing.T) {
+	// given
+	file := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := easycmd.New(easycmd.WithPreflight())
+
+	// when
+	missingErr := cmd.RunWithDir("ls", filepath.Join(t.TempDir(), "missing"))
+	notDirErr := cmd.RunWithDir("ls", file)
+
+	// then
+	var dirErr *easycmd.RunDirError
+	if !errors.As(missingErr, &dirErr) || !errors.Is(missingErr, fs.ErrNotExist) {
+		t.Errorf("expected RunDirError with ErrNotExist, got %v", missingErr)
+	}
+	if !errors.As(notDirErr, &dirErr) {
+		t.Errorf("expected RunDirError, got %v", notDirErr)
+	}
+}
+
+func TestWithPolicy(t *testing.T) {
+	// given
+	out := &bytes.Buffer{}
+	debugOut := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithDebug(debugOut),
+		easycmd.WithPolicy(easycmd.Policy{
+			AllowCommands: []string{"echo"},
+			DenyShell:     true,
+		}),
+	)
+
+	// when
+	allowedErr := cmd.Run("echo allowed")
+	deniedErr := cmd.Run("echo first && touch should-not-exist")
+	shellErr := cmd.RunShell("echo from shell")
+
+	// then
+	if allowedErr != nil {
+		t.Errorf("expected nil, got %v", allowedErr)
+	}
+	var violation *easycmd.PolicyViolation
+	if !errors.As(deniedErr, &violation) || violation.Rule != "AllowCommands" {
+		t.Errorf("expected AllowCommands violation, got %v", deniedErr)
+	}
+	if !errors.As(shellErr, &violation) || violation.Rule != "DenyShell" {
+		t.Errorf("expected DenyShell violation, got %v", shellErr)
+	}
+	if out.String() != "allowed\nfirst\n" {
+		t.Errorf("expected 'allowed\\nfirst\\n', got %q", out.String())
+	}
+	if !strings.Contains(debugOut.String(), "[DEBUG] 정책 위반:") {
+		t.Errorf("expected policy violation in debug output, got %s", debugOut.String())
+	}
+}
+
+func TestWithPolicyRunsCheckedExecutable(t *testing.T) {
+	// given - 명령어 앞의 PATH=로 찾은 실행 파일만 허용
+	dir := t.TempDir()
+	script := filepath.Join(dir, "ls")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\necho checked\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithPolicy(easycmd.Policy{AllowCommands: []string{script}}),
+	)
+
+	// when
+	err := cmd.Run("PATH=" + dir + " ls -d /")
+
+	// then - 정책이 검사한 실행 파일이 실행되어야 함
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if out.String() != "checked\n" {
+		t.Errorf("expected 'checked', got %q", out.String())
+	}
+}
+
+func TestWithAuditLog(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "audit.log")
+	auditLog, err := easycmd.OpenAuditLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := easycmd.New(easycmd.WithStdOut(&bytes.Buffer{}), easycmd.WithAuditLog(auditLog))
+
+	// when
+	cmd.Run("echo first")
+	cmd.Run("sh -c 'exit 3'")
+	auditLog.Close()
+
+	// 다시 열어 이어서 기록
+	reopened, err := easycmd.OpenAuditLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	easycmd.New(easycmd.WithStdOut(&bytes.Buffer{}), easycmd.WithAuditLog(reopened)).Run("echo third")
+	lastHash := reopened.LastHash()
+	reopened.Close()
+
+	// then
+	summary, err := easycmd.VerifyAuditLog(path)
+	if err != nil {
+		t.Fatalf("expected valid audit log, got %v", err)
+	}
+	if summary.Records != 3 || summary.LastHash != lastHash {
+		t.Errorf("expected 3 records ending with %s, got %+v", lastHash, summary)
+	}
+
+	data, _ := os.ReadFile(path)
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if !strings.Contains(lines[1], `"command":"sh"`) || !strings.Contains(lines[1], `"exit_code":3`) {
+		t.Errorf("unexpected second record: %s", lines[1])
+	}
+}
+
+func TestWithAuditLogSharedOutput(t *testing.T) {
+	// given - stdout과 stderr에 같은 Writer 사용
+	path := filepath.Join(t.TempDir(), "audit.log")
+	auditLog, err := easycmd.OpenAuditLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out), easycmd.WithStdErr(out), easycmd.WithAuditLog(auditLog))
+
+	// when
+	err = cmd.RunShell("for i in 1 2 3 4 5; do echo out$i; echo err$i >&2; done")
+	auditLog.Close()
+
+	// then - 출력 순서가 유지되고 합쳐진 출력의 해시가 기록되어야 함
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	var expected strings.Builder
+	for i := 1; i <= 5; i++ {
+		fmt.Fprintf(&expected, "out%d\nerr%d\n", i, i)
+	}
+	if out.String() != expected.String() {
+		t.Errorf("expected %q, got %q", expected.String(), out.String())
+	}
+	data, _ := os.ReadFile(path)
+	var record easycmd.AuditRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256([]byte(expected.String()))
+	digest := hex.EncodeToString(sum[:])
+	if record.StdoutSHA256 != digest || record.StderrSHA256 != digest {
+		t.Errorf("expected combined digest %s, got stdout %s, stderr %s", digest, record.StdoutSHA256, record.StderrSHA256)
+	}
+}
+
+func TestVerifyAuditLogTampered(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "audit.log")
+	auditLog, err := easycmd.OpenAuditLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := easycmd.New(easycmd.WithStdOut(&bytes.Buffer{}), easycmd.WithAuditLog(auditLog))
+	for i := 0; i < 3; i++ {
+		cmd.Run(fmt.Sprintf("echo %d", i))
+	}
+	auditLog.Close()
+	original, _ := os.ReadFile(path)
+	lines := strings.SplitAfter(string(original), "\n")
+
+	tests := []struct {
+		name     string
+		content  string
+		expected int // 문제가 발견되어야 하는 줄 번호
+	}{
+		{name: "수정", content: lines[0] + strings.Replace(lines[1], `"echo"`, `"rm"`, 1) + lines[2], expected: 2},
+		{name: "중간 레코드 삭제", content: lines[0] + lines[2], expected: 2},
+		{name: "마지막 레코드 잘림", content: lines[0] + lines[1] + lines[2][:10], expected: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := easycmd.VerifyAuditLog(path)
+
+			// then
+			var verifyErr *easycmd.AuditVerifyError
+			if !errors.As(err, &verifyErr) || verifyErr.Line != tt.expected {
+				t.Errorf("expected error at line %d, got %v", tt.expected, err)
+			}
+			if _, err := easycmd.OpenAuditLog(path); err == nil {
+				t.Error("expected OpenAuditLog to refuse tampered log")
+			}
+		})
+	}
+}
+
+func TestVerifyAuditLogWithAnchor(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "audit.log")
+	auditLog, err := easycmd.OpenAuditLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := easycmd.New(easycmd.WithStdOut(&bytes.Buffer{}), easycmd.WithAuditLog(auditLog))
+	cmd.Run("echo first")
+	cmd.Run("echo second")
+	anchor := auditLog.Summary()
+	cmd.Run("echo third")
+	auditLog.Close()
+	original, _ := os.ReadFile(path)
+	lines := strings.SplitAfter(string(original), "\n")
+
+	tests := []struct {
+		name     string
+		content  string
+		expected int // 문제가 발견되어야 하는 줄 번호 (0이면 성공)
+	}{
+		{name: "기준 이후 추가된 레코드", content: string(original)},
+		{name: "기준 이후 레코드 삭제", content: lines[0] + lines[1]},
+		{name: "기준 레코드까지 삭제", content: lines[0], expected: 2},
+		{name: "모든 레코드 삭제", content: "", expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := easycmd.VerifyAuditLogWithAnchor(path, anchor)
+			_, plainErr := easycmd.VerifyAuditLog(path)
+
+			// then
+			if plainErr != nil {
+				t.Errorf("expected VerifyAuditLog to accept truncated log, got %v", plainErr)
+			}
+			if tt.expected == 0 {
+				if err != nil {
+					t.Errorf("expected nil, got %v", err)
+				}
+				return
+			}
+			var verifyErr *easycmd.AuditVerifyError
+			if !errors.As(err, &verifyErr) || verifyErr.Line != tt.expected {
+				t.Errorf("expected error at line %d, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestWithResourceLimits(t *testing.T) {
+	// given
+	if runtime.GOOS != "linux" {
+		t.Skip("resource limits are only supported on Linux")
+	}
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithResourceLimits(
+			easycmd.LimitOpenFiles(64),
+			easycmd.LimitCoreSize(0),
+			easycmd.LimitAddressSpace(1<<30),
+		),
+	)
+
+	// when - 명령어가 시작하자마자 제한이 적용되어 있어야 함
+	err := cmd.Run("sh -c 'ulimit -n; ulimit -c; ulimit -v'")
+
+	// then
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if out.String() != "64\n0\n1048576\n" {
+		t.Errorf("expected limits 64, 0, 1048576, got %q", out.String())
+	}
+}
+
+func TestWithResourceLimitsAndCredential(t *testing.T) {
+	// given
+	if runtime.GOOS != "linux" || os.Geteuid() != 0 {
+		t.Skip("requires root on Linux")
+	}
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithCredential(65534, 65534),
+		easycmd.WithResourceLimits(easycmd.LimitOpenFiles(32)),
+	)
+
+	// when
+	err := cmd.Run("sh -c 'id -u; ulimit -n'")
+
+	// then
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if out.String() != "65534\n32\n" {
+		t.Errorf("expected uid 65534 and limit 32, got %q", out.String())
+	}
+}
+
+func TestWithResourceLimitsAboveHardLimit(t *testing.T) {
+	// given - 권한이 없으면 현재 hard 제한보다 크게 설정할 수 없음
+	if runtime.GOOS != "linux" || os.Geteuid() == 0 {
+		t.Skip("requires an unprivileged user on Linux")
+	}
+	hardOut := &bytes.Buffer{}
+	if err := easycmd.New(easycmd.WithStdOut(hardOut)).Run("sh -c 'ulimit -Hn'"); err != nil {
+		t.Fatal(err)
+	}
+	hard, err := strconv.ParseUint(strings.TrimSpace(hardOut.String()), 10, 64)
+	if err != nil {
+		t.Skipf("open files hard limit is not a number: %q", hardOut.String())
+	}
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithResourceLimits(easycmd.LimitOpenFiles(hard+100)),
+	)
+
+	// when
+	err = cmd.Run("sh -c 'ulimit -n'")
+
+	// then - hard 제한 안으로 맞춰 적용되어야 함
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if strings.TrimSpace(out.String()) != strconv.FormatUint(hard, 10) {
+		t.Errorf("expected limit %d, got %q", hard, out.String())
+	}
+}
+
+func TestWithResourceLimitsCPUExceeded(t *testing.T) {
+	// given
+	if runtime.GOOS != "linux" {
+		t.Skip("resource limits are only supported on Linux")
+	}
+	debugOut := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithDebug(debugOut),
+		easycmd.WithTimeoutSeconds(10),
+		easycmd.WithResourceLimits(easycmd.LimitCPUTime(time.Second)),
+	)
+
+	// when
+	err := cmd.Run("sh -c 'while :; do :; done'")
+
+	// then
+	var limitErr *easycmd.ResourceLimitError
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("expected ResourceLimitError, got %v", err)
+	}
+	if limitErr.Limit.String() != "CPUTime=1s" {
+		t.Errorf("expected CPUTime limit, got %s", limitErr.Limit)
+	}
+	if !strings.Contains(debugOut.String(), "[DEBUG] 리소스 제한 초과: CPUTime=1s") {
+		t.Errorf("expected limit exceeded in debug output, got %s", debugOut.String())
+	}
+}
+
+func TestWithCredential(t *testing.T) {
+	// given
+	if runtime.GOOS == "windows" || os.Geteuid() != 0 {
+		t.Skip("requires root on a Unix system")
+	}
+	out := &bytes.Buffer{}
+	debugOut := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithDebug(debugOut),
+		easycmd.WithCredential(65534, 65534),
+	)
+
+	// when
+	err := cmd.Run("id -u && id -g")
+
+	// then
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if out.String() != "65534\n65534\n" {
+		t.Errorf("expected uid/gid 65534, got %q", out.String())
+	}
+	if !strings.Contains(debugOut.String(), "[DEBUG] 실행 사용자: uid=65534 gid=65534") {
+		t.Errorf("expected credential in debug output, got %s", debugOut.String())
+	}
+}
+
+func TestWithUser(t *testing.T) {
+	// given
+	if runtime.GOOS == "windows" || os.Geteuid() != 0 {
+		t.Skip("requires root on a Unix system")
+	}
+	if _, err := user.Lookup("nobody"); err != nil {
+		t.Skip("user nobody does not exist")
+	}
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out), easycmd.WithUser("nobody"))
+
+	// when
+	err := cmd.Run("id -un")
+
+	// then
+	if cmd.Err() != nil || err != nil {
+		t.Fatalf("expected nil, got %v, %v", cmd.Err(), err)
+	}
+	if out.String() != "nobody\n" {
+		t.Errorf("expected 'nobody', got %q", out.String())
+	}
+}
+
+func TestWithAuditLogCredential(t *testing.T) {
+	// given
+	if runtime.GOOS == "windows" || os.Geteuid() != 0 {
+		t.Skip("requires root on a Unix system")
+	}
+	if _, err := user.Lookup("nobody"); err != nil {
+		t.Skip("user nobody does not exist")
+	}
+	path := filepath.Join(t.TempDir(), "audit.log")
+	auditLog, err := easycmd.OpenAuditLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(&bytes.Buffer{}),
+		easycmd.WithUser("nobody"),
+		easycmd.WithAuditLog(auditLog),
+	)
+
+	// when
+	err = cmd.Run("id -un")
+	auditLog.Close()
+
+	// then - 명령어를 실행한 사용자가 기록되어야 함
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	var record easycmd.AuditRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		t.Fatal(err)
+	}
+	if record.RunAs == nil || record.RunAs.Username != "nobody" || record.RunAs.UID == 0 {
+		t.Errorf("expected run_as nobody, got %+v", record.RunAs)
+	}
+	if _, err := easycmd.VerifyAuditLog(path); err != nil {
+		t.Errorf("expected valid audit log, got %v", err)
+	}
+}
+
+func TestWithUserNotFound(t *testing.T) {
+	// given
+	cmd := easycmd.New(easycmd.WithUser("easycmd-no-such-user"))
+
+	// when
+	err := cmd.Run("echo should not run")
+
+	// then
+	if cmd.Err() == nil {
+		t.Fatal("expected New to record the lookup error")
+	}
+	if !errors.Is(err, cmd.Err()) {
+		t.Errorf("expected Run to return %v, got %v", cmd.Err(), err)
+	}
+}
+
+func TestWithCredentialRedirectRefused(t *testing.T) {
+	// given
+	if runtime.GOOS == "windows" || os.Geteuid() != 0 {
+		t.Skip("requires root on a Unix system")
+	}
+	target := filepath.Join(t.TempDir(), "out.txt")
+	cmd := easycmd.New(easycmd.WithCredential(65534, 65534))
+
+	// when - 리다이렉션 파일은 root 권한으로 열리므로 거부되어야 함
+	err := cmd.Run("echo secret > " + target)
+	_, pipelineErr := cmd.RunPipeline("echo secret", "cat > "+target)
+
+	// then
+	if err == nil || !strings.Contains(err.Error(), "리다이렉션") {
+		t.Errorf("expected redirection error, got %v", err)
+	}
+	if pipelineErr == nil || !strings.Contains(pipelineErr.Error(), "리다이렉션") {
+		t.Errorf("expected redirection error for pipeline, got %v", pipelineErr)
+	}
+	if _, statErr := os.Stat(target); !os.IsNotExist(statErr) {
+		t.Errorf("expected %s not to be created, got %v", target, statErr)
+	}
+}
+
+func TestRunScriptWithUser(t *testing.T) {
+	// given
+	if runtime.GOOS == "windows" || os.Geteuid() != 0 {
+		t.Skip("requires root on a Unix system")
+	}
+	scripts := fstest.MapFS{
+		"whoami.sh": {Data: []byte("#!/bin/sh\nid -u\n")},
+	}
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out), easycmd.WithUser("nobody"))
+
+	// when - 임시 디렉토리(0700)의 스크립트를 실행 사용자가 읽을 수 있어야 함
+	err := cmd.RunScript(scripts, "whoami.sh")
+
+	// then
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if out.String() != "65534\n" {
+		t.Errorf("expected uid 65534, got %q", out.String())
+	}
+}
+
+func TestWithShellStrictWithUser(t *testing.T) {
+	// given
+	if runtime.GOOS == "windows" || os.Geteuid() != 0 {
+		t.Skip("requires root on a Unix system")
+	}
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not installed, skipping test")
+	}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(&bytes.Buffer{}),
+		easycmd.WithShellPreset(easycmd.ShellBash),
+		easycmd.WithShellStrict(),
+		easycmd.WithUser("nobody"),
+	)
+
+	// when - 실행 사용자가 줄 번호 파일(0600)에 쓸 수 있어야 함
+	err := cmd.RunShell("echo first\nfalse\necho unreachable")
+
+	// then
+	var scriptErr *easycmd.ScriptError
+	if !errors.As(err, &scriptErr) {
+		t.Fatalf("expected ScriptError, got %v", err)
+	}
+	if scriptErr.Line != 2 {
+		t.Errorf("expected line 2, got %d", scriptErr.Line)
+	}
+}
+
+func TestWithNiceAndIOPriority(t *testing.T) {
+	// given
+	if runtime.GOOS != "linux" {
+		t.Skip("reads priority from /proc on Linux")
+	}
+	if _, err := exec.LookPath("ionice"); err != nil {
+		t.Skip("ionice not installed, skipping test")
+	}
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithNice(10),
+		easycmd.WithIOPriority(easycmd.IOPriorityBestEffort, 6),
+	)
+
+	// when - /proc/<pid>/stat의 19번째 필드가 nice 값, HelperMain으로 명령어가 시작하기 전에 적용되어야 함
+	err := cmd.Run(`sh -c 'cut -d " " -f 19 /proc/$$/stat; ionice -p $$'`)
+
+	// then
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if out.String() != "10\nbest-effort: prio 6\n" {
+		t.Errorf("expected nice 10 and best-effort prio 6, got %q", out.String())
+	}
+}
+
+func TestWithNiceInvalid(t *testing.T) {
+	// given
+	cmd := easycmd.New(easycmd.WithNice(20))
+
+	// when
+	err := cmd.Run("echo should not run")
+
+	// then
+	if cmd.Err() == nil || !errors.Is(err, cmd.Err()) {
+		t.Errorf("expected validation error, got %v, %v", cmd.Err(), err)
+	}
+}
+
+// skipWithoutSandbox 사용자 네임스페이스를 만들 수 없는 환경이면 테스트를 건너뜁니다
+func skipWithoutSandbox(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		t.Skip("sandbox requires Linux namespaces")
+	}
+	err := easycmd.New(easycmd.WithSandbox(easycmd.Sandbox{})).Run("true")
+	if err != nil && strings.Contains(err.Error(), "샌드박스를 시작할 수 없습니다") {
+		t.Skipf("user namespaces are not available: %v", err)
+	}
+}
+
+func TestWithSandbox(t *testing.T) {
+	// given
+	skipWithoutSandbox(t)
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "script.sh"), []byte("echo hello\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(
+		easycmd.WithStdOut(out),
+		easycmd.WithSandbox(easycmd.Sandbox{
+			BindMounts: []easycmd.BindMount{{Source: dir, Target: "/tmp/work"}},
+			Tmpfs:      []string{"/tmp"},
+			Hostname:   "easycmd-test",
+		}),
+	)
+
+	// when - PID 1로 실행되고, 바인드 마운트는 읽기 전용이며, /tmp에는 마운트 지점만 있어야 함
+	err := cmd.Run(`sh -c 'echo $$; id -u; hostname; sh /tmp/work/script.sh; touch /tmp/work/new 2>/dev/null || echo read-only; ls -A /tmp'`)
+
+	// then
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	expected := "1\n0\neasycmd-test\nhello\nread-only\nwork\n"
+	if out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+	if _, err := os.Stat(filepath.Join(dir, "new")); err == nil {
+		t.Error("expected the bind mount to be read-only")
+	}
+}
+
+func TestWithSandboxMissingMountPoint(t *testing.T) {
+	// given
+	skipWithoutSandbox(t)
+	target := "/easycmd-no-such-mount-point"
+	cmd := easycmd.New(easycmd.WithSandbox(easycmd.Sandbox{
+		BindMounts: []easycmd.BindMount{{Source: t.TempDir(), Target: target}},
+	}))
+
+	// when - tmpfs 밖의 없는 마운트 지점은 호스트에 만들지 않고 실패해야 함
+	err := cmd.Run("echo should not run")
+
+	// then
+	if err == nil || !strings.Contains(err.Error(), "마운트 지점이 없습니다") {
+		t.Errorf("expected missing mount point error, got %v", err)
+	}
+	if _, statErr := os.Lstat(target); !os.IsNotExist(statErr) {
+		os.Remove(target)
+		t.Errorf("expected %s not to be created on the host, got %v", target, statErr)
+	}
+}
+
+func TestWithSandboxNetwork(t *testing.T) {
+	// given
+	skipWithoutSandbox(t)
+	out := &bytes.Buffer{}
+	cmd := easycmd.New(easycmd.WithStdOut(out), easycmd.WithSandbox(easycmd.Sandbox{}))
+
+	// when - 새 네트워크 네임스페이스에는 lo만 있어야 함
+	err := cmd.Run("ls /sys/class/net")
+
+	// then
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if out.String() != "lo\n" {
+		t.Errorf("expected only loopback interface, got %q", out.String())
+	}
+}
+
+func TestWithSandboxSetupError(t *testing.T) {
+	// given
+	skipWithoutSandbox(t)
+	cmd := easycmd.New(easycmd.WithSandbox(easycmd.Sandbox{
+		BindMounts: []easycmd.BindMount{{Source: "/easycmd-no-such-dir"}},
+	}))
+
+	// when
+	err := cmd.Run("echo should not run")
+
+	// then
+	if err == nil || !strings.Contains(err.Error(), "샌드박스를 설정할 수 없습니다") {
+		t.Errorf("expected sandbox setup error, got %v", err)
+	}
+}